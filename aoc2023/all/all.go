@@ -0,0 +1,30 @@
+// Package all registers every 2023 solution with the solver registry.
+package all
+
+import (
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day01"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day02"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day03"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day04"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day05"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day06"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day07"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day08"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day09"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day10"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day11"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day12"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day13"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day14"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day15"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day16"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day17"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day18"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day19"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day20"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day21"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day22"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day23"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day24"
+	_ "github.com/mikehelmick/adventofcode/aoc2023/day25"
+)
@@ -1,12 +1,14 @@
-package main
+package day01
 
 import (
 	"bufio"
-	"os"
+	"context"
+	"io"
 	"sort"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
 var (
@@ -16,6 +18,10 @@ var (
 		"1": 1, "2": 2, "3": 3, "4": 4, "5": 5, "6": 6, "7": 7, "8": 8, "9": 9}
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 1, Part1: Part1, Part2: Part2})
+}
+
 type Digit struct {
 	Pos   int
 	Value int
@@ -41,31 +47,32 @@ func indexDigits(s string, m map[string]int) Index {
 	return rtn
 }
 
-func main() {
-	log := logging.DefaultLogger()
-	scanner := bufio.NewScanner(os.Stdin)
+// Part1 sums the first and last digit on each line.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	return calibrate(ctx, r, partOne)
+}
+
+// Part2 also counts digits that are spelled out.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	return calibrate(ctx, r, partTwo)
+}
 
-	part1 := 0
-	part2 := 0
+func calibrate(ctx context.Context, r io.Reader, digits map[string]int) (int, error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
+
+	sum := 0
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
 
-		p1idx := indexDigits(line, partOne)
-		if len(p1idx) > 0 { // some examples in the p2 example don't parse
-			part1 += (p1idx[0].Value*10 + p1idx[len(p1idx)-1].Value)
+		idx := indexDigits(line, digits)
+		log.Debugf("%v -> %+v", line, idx)
+		if len(idx) > 0 { // some examples in the p2 example don't parse
+			sum += (idx[0].Value*10 + idx[len(idx)-1].Value)
 		}
-
-		p2idx := indexDigits(line, partTwo)
-		log.Debugf("%v -> %+v", line, p2idx)
-		part2 += (p2idx[0].Value*10 + p2idx[len(p2idx)-1].Value)
 	}
-	if err := scanner.Err(); err != nil {
-		log.Errorw("io error", "error", err)
-	}
-
-	log.Infow("part1", "answer", part1)
-	log.Infow("part2", "answer", part2)
+	return sum, scanner.Err()
 }
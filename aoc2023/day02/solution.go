@@ -1,26 +1,29 @@
-package main
+package day02
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os"
-	"strconv"
-	"strings"
+	"io"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/go-functional/maps"
 	"github.com/mikehelmick/go-functional/slice"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 2, Part1: Part1, Part2: Part2})
+}
+
 // Show is an individual set of cubes you were shown.
 type Show struct {
 	Cubes map[string]int
 }
 
 // Given a set of stones, was this show possible?
-func (s *Show) Possible(bag map[string]int) bool {
-	log := logging.DefaultLogger()
+func (s *Show) Possible(ctx context.Context, bag map[string]int) bool {
+	log := logging.FromContext(ctx)
 	for color, amt := range s.Cubes {
 		// if we were shown more than the bag amount, not possible.
 		if amt > bag[color] {
@@ -59,87 +62,70 @@ func (g *Game) Fewest() map[string]int {
 }
 
 // Possible says if a game is possible w/ the given amount of cubes.
-func (g *Game) Possible(bag map[string]int) bool {
-	return slice.All(g.Shows, func(s Show) bool { return s.Possible(bag) })
+func (g *Game) Possible(ctx context.Context, bag map[string]int) bool {
+	return slice.All(g.Shows, func(s Show) bool { return s.Possible(ctx, bag) })
 }
 
 func (g *Game) String() string {
 	return fmt.Sprintf("Game %d: %+v", g.ID, g.Shows)
 }
 
-func ParseLine(l string) *Game {
-	parts := strings.Split(l, ":")
-
-	gid := strings.Split(parts[0], " ")
-	id, err := strconv.Atoi(gid[1])
-	if err != nil {
-		panic(err)
-	}
-
-	game := &Game{
-		ID:    id,
-		Shows: make([]Show, 0),
-	}
-
-	shown := strings.Split(parts[1], ";")
-	for _, s := range shown {
-		s = strings.TrimSpace(s)
-		countColors := strings.Split(s, ",")
-
-		show := Show{
-			Cubes: make(map[string]int),
-		}
+// gamePattern is a game's line, like "Game 1: 3 blue, 4 red; 1 red".
+var gamePattern = parse.MustCompile("Game {id}: {shows|;|{cubes|,|{value} {key}}}")
 
-		for _, cc := range countColors {
-			cc = strings.TrimSpace(cc)
-			countColor := strings.Split(cc, " ")
-			count, err := strconv.Atoi(countColor[0])
-			if err != nil {
-				panic(err)
-			}
-			show.Cubes[countColor[1]] = count
-		}
-		game.Shows = append(game.Shows, show)
+// ParseLine parses one game.
+func ParseLine(l parse.Field) (*Game, error) {
+	game := &Game{}
+	if err := gamePattern.Match(l, game); err != nil {
+		return nil, err
 	}
-
-	return game
+	return game, nil
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+func load(ctx context.Context, r io.Reader) ([]*Game, error) {
 	log := logging.FromContext(ctx)
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
 
-	scanner := bufio.NewScanner(os.Stdin)
-
-	games := make([]*Game, 0)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
+	games := make([]*Game, 0, len(lines))
+	for _, line := range lines {
+		game, err := ParseLine(line)
+		if err != nil {
+			return nil, err
 		}
-		game := ParseLine(line)
 		log.Debugw("loaded", "game", game)
 		games = append(games, game)
 	}
+	return games, nil
+}
+
+// Part1 finds which games are possible w/ this number of cubes.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	games, err := load(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
-	// Part one, find which games are possible w/ this number of cubes.
 	bag := map[string]int{"red": 12, "green": 13, "blue": 14}
-	part1 := slice.FoldL(games, 0, func(game *Game, acc int) int {
-		if game.Possible(bag) {
+	return slice.FoldL(games, 0, func(game *Game, acc int) int {
+		if game.Possible(ctx, bag) {
 			return acc + game.ID
 		}
 		return acc
-	})
-	log.Infow("part1", "answer", part1)
+	}), nil
+}
+
+// Part2 sums the power of every game.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	games, err := load(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
-	// part 2
-	part2 := slice.FoldL(games, 0,
+	return slice.FoldL(games, 0,
 		func(g *Game, acc int) int {
 			return acc + g.Power()
-		})
-	log.Infow("part2", "answer", part2)
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
-	}
+		}), nil
 }
@@ -1,15 +1,20 @@
-package main
+package day03
 
 import (
 	"bufio"
 	"context"
-	"os"
+	"io"
 	"strconv"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/adventofcode/pkg/twod"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 3, Part1: Part1, Part2: Part2})
+}
+
 var (
 	digits = map[string]bool{
 		"0": true, "1": true, "2": true, "3": true, "4": true, "5": true, "6": true, "7": true, "8": true, "9": true,
@@ -103,11 +108,9 @@ func ValidFunc(grid []string) twod.ValidFunc {
 	}
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+func parse(ctx context.Context, r io.Reader) ([]string, []Number, error) {
 	log := logging.FromContext(ctx)
-
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := bufio.NewScanner(r)
 
 	grid := make([]string, 0)
 
@@ -119,21 +122,41 @@ func main() {
 		grid = append(grid, line)
 		log.Debugw("parsed line", "line", line)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
 
 	numbers := FindNumbers(grid)
 	log.Debugw("found numbers", "numbers", numbers)
+	return grid, numbers, nil
+}
+
+// Part1 adds up all the numbers that are symbol adjacent.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	grid, numbers, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
 	var part1 int64
-	// Just add up all the numbers that are symbol adjacent.
 	for _, n := range numbers {
 		if n.SymbolAdjacent(grid) {
 			log.Debugw("symbol adjacent", "row", n.Pos.Row, "col", n.Pos.Col, "value", n.Value)
 			part1 += n.Value
 		}
 	}
-	log.Infow("part 1", "answer", part1)
+	return part1, nil
+}
+
+// Part2 adds up the gear ratios.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	grid, numbers, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
-	// part 2
 	// Create a map of all the stars to the numbers they are adjacent to.
 	starMap := make(map[twod.Pos][]Number)
 	// Do this by going over every number
@@ -161,9 +184,5 @@ func main() {
 		ratio := adjNum[0].Value * adjNum[1].Value
 		part2 += ratio
 	}
-	log.Infow("part 2", "answer", part2)
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
-	}
+	return part2, nil
 }
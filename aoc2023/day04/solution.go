@@ -1,17 +1,20 @@
-package main
+package day04
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os"
-	"strconv"
+	"io"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
-	"github.com/mikehelmick/go-functional/slice"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 4, Part1: Part1, Part2: Part2})
+}
+
 type Card struct {
 	ID      int
 	Numbers []string
@@ -55,71 +58,79 @@ func (c *Card) String() string {
 		strings.Join(w, ","), c.Points())
 }
 
-// NewCard pares a line of text into a card.
-func NewCard(s string) *Card {
-	p := strings.Split(s, ":")
-	nums := strings.Split(p[1], "|")
+// cardPattern is a card's line, like "Card 1: 41 48 83 | 83 86  6".
+var cardPattern = parse.MustCompile("Card {id}: {numbers} | {winners}")
 
-	id := strings.TrimSpace(strings.Split(p[0], "d")[1])
+// NewCard parses a line of text into a card.
+func NewCard(line parse.Field) (*Card, error) {
+	var fields struct {
+		ID      int
+		Numbers []string
+		Winners []string
+	}
+	if err := cardPattern.Match(line, &fields); err != nil {
+		return nil, err
+	}
 
-	numbers := strings.Split(strings.TrimSpace(nums[0]), " ")
-	winners := strings.Split(strings.TrimSpace(nums[1]), " ")
+	winMap := make(map[string]bool, len(fields.Winners))
+	for _, w := range fields.Winners {
+		winMap[w] = true
+	}
+	return &Card{
+		ID:      fields.ID,
+		Numbers: fields.Numbers,
+		Winners: winMap,
+	}, nil
+}
 
-	winMap := make(map[string]bool, len(winners))
-	for _, w := range winners {
-		w = strings.TrimSpace(w)
-		if w != " " {
-			winMap[w] = true
-		}
+func load(ctx context.Context, r io.Reader) ([]*Card, error) {
+	log := logging.FromContext(ctx)
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
 	}
 
-	keep := make([]string, 0, len(numbers))
-	for _, n := range numbers {
-		if n != "" {
-			keep = append(keep, n)
+	cards := make([]*Card, 0, len(lines))
+	for _, line := range lines {
+		card, err := NewCard(line)
+		if err != nil {
+			return nil, err
 		}
+		log.Debugw("loaded", "card", card.String())
+		cards = append(cards, card)
 	}
-	numbers = slice.Map(keep, func(s string) string {
-		return strings.TrimSpace(s)
-	})
+	return cards, nil
+}
 
-	idNum, err := strconv.Atoi(id)
+// Part1 adds up the points on every card.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	cards, err := load(ctx, r)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	return &Card{
-		ID:      idNum,
-		Numbers: numbers,
-		Winners: winMap,
+	part1 := 0
+	for _, card := range cards {
+		part1 += card.Points()
 	}
+	return part1, nil
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+// Part2 counts the cards once the won copies are scratched as well.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
 	log := logging.FromContext(ctx)
+	cards, err := load(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
-	scanner := bufio.NewScanner(os.Stdin)
-
-	cards := make([]*Card, 0)
 	cardMap := make(map[int]*Card)
 	copies := make(map[int]int)
-	part1 := 0
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-		card := NewCard(line)
-		log.Debugw("loaded", "card", card.String())
-		cards = append(cards, card)
+	for _, card := range cards {
 		cardMap[card.ID] = card
 		copies[card.ID] = 1
-		part1 += card.Points()
 	}
 
-	log.Infow("answer", "part1", part1)
-
 	cardCount := len(cards)
 	// while the card count map isn't empty, seeded w/ 1 copy of every card
 	for len(copies) > 0 {
@@ -147,9 +158,5 @@ func main() {
 		}
 		copies = next
 	}
-	log.Infow("answer", "part2", cardCount)
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
-	}
+	return cardCount, nil
 }
@@ -0,0 +1,142 @@
+// Day 5 solution.
+
+package day05
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/interval"
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 5, Part1: Part1, Part2: Part2})
+}
+
+// Almanac is the parsed puzzle input.
+type Almanac struct {
+	Seeds []int64
+	// Maps are keyed by their name, like "seed-to-soil".
+	Maps        map[string]interval.Mapping
+	Conversions map[string]string
+}
+
+func load(ctx context.Context, r io.Reader) (*Almanac, error) {
+	log := logging.FromContext(ctx)
+	sections, err := parse.Sections(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("no seeds in the input")
+	}
+
+	seedsLine := sections[0][0]
+	_, list, err := seedsLine.Cut("seeds:")
+	if err != nil {
+		return nil, err
+	}
+	ints, err := list.SplitInts()
+	if err != nil {
+		return nil, err
+	}
+	if len(ints) == 0 {
+		return nil, seedsLine.Errorf("no seeds")
+	}
+	seeds := make([]int64, len(ints))
+	for i, v := range ints {
+		seeds[i] = int64(v)
+	}
+	log.Debugw("loaded seeds", "seeds", seeds)
+
+	maps := make(map[string]interval.Mapping)
+	conversions := make(map[string]string)
+	for _, section := range sections[1:] {
+		header := section[0]
+		name, ok := strings.CutSuffix(header.Text, " map:")
+		if !ok {
+			return nil, header.Errorf("want a map header like \"a-to-b map:\", got %q", header.Text)
+		}
+		from, to, ok := strings.Cut(name, "-to-")
+		if !ok {
+			return nil, header.Errorf("invalid map name %q", name)
+		}
+		m, err := interval.ParseMapping(section[1:])
+		if err != nil {
+			return nil, err
+		}
+		log.Debugw("conversion", "from", from, "to", to, "mapping", m)
+		maps[name] = m
+		conversions[from] = to
+	}
+	return &Almanac{Seeds: seeds, Maps: maps, Conversions: conversions}, nil
+}
+
+// Chain composes the maps from seed to location into one mapping. Maps
+// that lead back to a category already passed through are an error.
+func (a *Almanac) Chain() (interval.Mapping, error) {
+	var chain interval.Mapping
+	seen := map[string]bool{"seed": true}
+	for current := "seed"; current != "location"; {
+		next, ok := a.Conversions[current]
+		if !ok {
+			return interval.Mapping{}, fmt.Errorf("no map from %q", current)
+		}
+		if seen[next] {
+			return interval.Mapping{}, fmt.Errorf("map %q loops back to %q", current+"-to-"+next, next)
+		}
+		seen[next] = true
+		chain = chain.Then(a.Maps[current+"-to-"+next])
+		current = next
+	}
+	return chain, nil
+}
+
+// Part1 finds the lowest location of the individual seeds.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	a, err := load(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := a.Chain()
+	if err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Debugw("seed to location", "mapping", chain)
+
+	lowest := chain.Map(a.Seeds[0])
+	for _, s := range a.Seeds[1:] {
+		lowest = min(lowest, chain.Map(s))
+	}
+	return lowest, nil
+}
+
+// Part2 treats the seeds as pairs of start and length.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	a, err := load(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := a.Chain()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(a.Seeds)%2 != 0 {
+		return nil, fmt.Errorf("%d seed numbers, want pairs of start and length", len(a.Seeds))
+	}
+	var seeds interval.Set[int64]
+	for i := 0; i < len(a.Seeds); i += 2 {
+		seeds = seeds.Add(interval.Length(a.Seeds[i], a.Seeds[i+1]))
+	}
+	lowest, ok := chain.Apply(seeds).Min()
+	if !ok {
+		return nil, fmt.Errorf("no seeds in the ranges")
+	}
+	return lowest, nil
+}
@@ -1,16 +1,21 @@
-package main
+package day06
 
 import (
 	"bufio"
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 6, Part1: Part1, Part2: Part2})
+}
+
 type Race struct {
 	Time     int64
 	Distance int64
@@ -61,11 +66,9 @@ func mergedValue(line string, kind string) int64 {
 	return num
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+func parse(ctx context.Context, r io.Reader) ([]*Race, *Race, error) {
 	log := logging.FromContext(ctx)
-
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := bufio.NewScanner(r)
 
 	races := make([]*Race, 0)
 	part2Race := &Race{}
@@ -88,6 +91,16 @@ func main() {
 		}
 	}
 	log.Debugw("loaded races", "races", races)
+	return races, part2Race, scanner.Err()
+}
+
+// Part1 multiplies the ways to beat each race.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	races, _, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
 	part1 := int64(1)
 	for i, r := range races {
@@ -95,13 +108,17 @@ func main() {
 		log.Debugw("ways to beat", "id", i, "race", r, "wins", wins)
 		part1 *= wins
 	}
-	log.Infow("answer", "part1", part1)
-
-	log.Debugw("merged race", "race", part2Race)
-	part2 := part2Race.WaysToBeat()
-	log.Infow("answer", "part2", part2)
+	return part1, nil
+}
 
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
+// Part2 ignores the spaces, making it one long race.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	_, part2Race, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
 	}
+
+	log.Debugw("merged race", "race", part2Race)
+	return part2Race.WaysToBeat(), nil
 }
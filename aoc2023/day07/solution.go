@@ -1,14 +1,16 @@
-package main
+package day07
 
 import (
 	"bufio"
 	"context"
-	"os"
+	"io"
 	"sort"
 	"strconv"
 	"strings"
 
+	"github.com/mikehelmick/adventofcode/pkg/counter"
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
 var tieBreak = map[string]int{}
@@ -21,6 +23,8 @@ func init() {
 		tieBreak[in[i:i+1]] = i
 		tieBreakPart2[in2[i:i+1]] = i
 	}
+
+	solver.Register(&solver.Day{Year: 2023, Day: 7, Part1: Part1, Part2: Part2})
 }
 
 type HandType int
@@ -45,17 +49,8 @@ func (c *Camel) CardAt(i int) string {
 	return c.Hand[i : i+1]
 }
 
-func (c *Camel) Hits() map[string]int {
-	hits := make(map[string]int)
-	for i := 0; i < len(c.Hand); i++ {
-		card := c.Hand[i : i+1]
-		if v, ok := hits[card]; !ok {
-			hits[card] = 1
-		} else {
-			hits[card] = v + 1
-		}
-	}
-	return hits
+func (c *Camel) Hits() counter.Counter[string] {
+	return counter.New(strings.Split(c.Hand, "")...)
 }
 
 // Creates a camel hand from the input line
@@ -145,20 +140,16 @@ func Score(c *Camel) HandType {
 
 	if len(hits) == 2 {
 		// could be four_kind, or full_house
-		for _, v := range hits {
-			if v == 4 {
-				return FOUR_KIND
-			}
+		if hits.MostCommon(1)[0].Count == 4 {
+			return FOUR_KIND
 		}
 		return FULL_HOUSE
 	}
 
 	if len(hits) == 3 {
 		// must be 3 of a kind or 2 pair
-		for _, v := range hits {
-			if v == 3 {
-				return THREE_KIND
-			}
+		if hits.MostCommon(1)[0].Count == 3 {
+			return THREE_KIND
 		}
 		return TWO_PAIR
 	}
@@ -195,13 +186,10 @@ func (c *Camel) StrongerThan(o *Camel, score ScoreFn, tieBreak map[string]int) b
 	return false
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+func parse(ctx context.Context, r io.Reader) ([]*Camel, error) {
 	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
 
-	scanner := bufio.NewScanner(os.Stdin)
-
-	// Load input
 	hands := make([]*Camel, 0)
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -211,30 +199,37 @@ func main() {
 		hands = append(hands, NewCamel(line))
 	}
 	log.Debugw("loaded hands", "hands", hands)
+	return hands, scanner.Err()
+}
 
-	// Part 1, using first scoring function.
-	sort.Slice(hands, func(i, j int) bool {
-		return !hands[i].StrongerThan(hands[j], Score, tieBreak)
-	})
-	part1 := 0
-	for i, h := range hands {
-		log.Debugw("output", "rank", i+1, "hand", h.Hand, "bid", h.Bid, "score", Score(h))
-		part1 += ((i + 1) * h.Bid)
+// Part1 ranks the hands using the first scoring function.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	hands, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
 	}
-	log.Infow("answer", "part1", part1)
+	return winnings(ctx, hands, Score, tieBreak), nil
+}
+
+// Part2 ranks the hands with J as a joker.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	hands, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return winnings(ctx, hands, Score2, tieBreakPart2), nil
+}
+
+func winnings(ctx context.Context, hands []*Camel, score ScoreFn, tieBreak map[string]int) int {
+	log := logging.FromContext(ctx)
 
-	// Part 2 using second scoring function.
 	sort.Slice(hands, func(i, j int) bool {
-		return !hands[i].StrongerThan(hands[j], Score2, tieBreakPart2)
+		return !hands[i].StrongerThan(hands[j], score, tieBreak)
 	})
-	part2 := 0
+	total := 0
 	for i, h := range hands {
-		log.Debugw("output", "rank", i+1, "hand", h.Hand, "bid", h.Bid, "score", Score2(h))
-		part2 += ((i + 1) * h.Bid)
-	}
-	log.Infow("answer", "part2", part2)
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
+		log.Debugw("output", "rank", i+1, "hand", h.Hand, "bid", h.Bid, "score", score(h))
+		total += ((i + 1) * h.Bid)
 	}
+	return total
 }
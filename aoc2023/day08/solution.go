@@ -1,15 +1,20 @@
-package main
+package day08
 
 import (
 	"bufio"
 	"context"
-	"os"
+	"io"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
 	"github.com/mikehelmick/adventofcode/pkg/mathaid"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 8, Part1: Part1, Part2: Part2})
+}
+
 type Node struct {
 	Name  string
 	Left  string
@@ -54,11 +59,9 @@ func Traverse(start string, isFinished FinishFn, directions string, nodes map[st
 	return steps
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+func parse(ctx context.Context, r io.Reader) (string, map[string]*Node, error) {
 	log := logging.FromContext(ctx)
-
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := bufio.NewScanner(r)
 
 	directions := ""
 
@@ -77,13 +80,26 @@ func main() {
 		}
 	}
 	log.Debugw("loaded", "directions", directions)
+	return directions, nodes, scanner.Err()
+}
 
-	// part1
-	steps := Traverse("AAA", func(s string) bool { return s == "ZZZ" }, directions, nodes)
-	log.Infow("answer", "part1", steps)
+// Part1 counts the steps from AAA to ZZZ.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	directions, nodes, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return Traverse("AAA", func(s string) bool { return s == "ZZZ" }, directions, nodes), nil
+}
+
+// Part2 goes from start to finish for all starts at the same time.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	directions, nodes, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
-	// part 2
-	// start to finish for all starts
 	starts := make([]string, 0)
 	for _, n := range nodes {
 		if strings.HasSuffix(n.Name, "A") {
@@ -99,10 +115,5 @@ func main() {
 	}
 	log.Debugw("stepsToZ", "steps", stepsToZ)
 
-	answer := mathaid.LowestCommonMultiple(stepsToZ[0], stepsToZ[1], stepsToZ[2:]...)
-	log.Infow("answer", "part2", answer)
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
-	}
+	return mathaid.LowestCommonMultiple(stepsToZ[0], stepsToZ[1], stepsToZ[2:]...), nil
 }
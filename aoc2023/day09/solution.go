@@ -1,15 +1,20 @@
-package main
+package day09
 
 import (
 	"bufio"
 	"context"
-	"os"
+	"io"
 	"strconv"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 9, Part1: Part1, Part2: Part2})
+}
+
 type Oasis struct {
 	Rows [][]int
 }
@@ -98,24 +103,29 @@ func New(s string) *Oasis {
 	}
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+func parse(ctx context.Context, r io.Reader) ([]*Oasis, error) {
 	log := logging.FromContext(ctx)
-
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := bufio.NewScanner(r)
 
 	lines := make([]*Oasis, 0)
-	// kind of inefficient, but was faster to just create 2 copies of the input.
-	p2lines := make([]*Oasis, 0)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
 		lines = append(lines, New(line))
-		p2lines = append(p2lines, New(line))
 	}
 	log.Debugw("loaded", "oasis", lines)
+	return lines, scanner.Err()
+}
+
+// Part1 extrapolates the next value of each history.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	lines, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
 	part1 := 0
 	for i, o := range lines {
@@ -125,19 +135,24 @@ func main() {
 		log.Debugw("expand", "i", i, "oasis", o)
 		part1 += o.LastValFromFirstRow()
 	}
-	log.Infow("answer", "part1", part1)
+	return part1, nil
+}
+
+// Part2 extrapolates backwards.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	lines, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
 	part2 := 0
-	for i, o := range p2lines {
+	for i, o := range lines {
 		o.Fill()
 		log.Debugw("filled", "i", i, "oasis", o)
 		o.ExpandLeft()
 		log.Debugw("expand", "i", i, "oasis", o)
 		part2 += o.FirstValFromFirstRow()
 	}
-	log.Infow("answer", "part2", part2)
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
-	}
+	return part2, nil
 }
@@ -0,0 +1,211 @@
+package day10
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/mathaid"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/adventofcode/pkg/twod"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 10, Part1: Part1, Part2: Part2})
+}
+
+var connections = map[string][]*twod.Pos{
+	"|": {twod.NewPos(-1, 0), twod.NewPos(1, 0)},
+	"-": {twod.NewPos(0, -1), twod.NewPos(0, 1)},
+	"L": {twod.NewPos(-1, 0), twod.NewPos(0, 1)},
+	"J": {twod.NewPos(-1, 0), twod.NewPos(0, -1)},
+	"7": {twod.NewPos(1, 0), twod.NewPos(0, -1)},
+	"F": {twod.NewPos(1, 0), twod.NewPos(0, 1)},
+	".": {},
+	"S": {twod.NewPos(-1, 0), twod.NewPos(0, -1)}, // my starting input is a J
+	// You'd have to change this to the correct character for your input or examples.
+}
+
+func findStart(grid []string) *twod.Pos {
+	for r, row := range grid {
+		if c := strings.Index(row, "S"); c >= 0 {
+			return twod.NewPos(r, c)
+		}
+	}
+	panic("no start found")
+}
+
+// does a BFS from the starting point to find the farthest point in the loop.
+func findFurthest(grid []string, dist [][]int) int {
+	validFunc := func(p *twod.Pos) bool {
+		return p.Row >= 0 && p.Col >= 0 &&
+			p.Row < len(grid) && p.Col < len(grid[0])
+	}
+
+	start := findStart(grid)
+
+	maxSetDist := 0
+	dist[start.Row][start.Col] = 0
+	distance := 0
+	wave := []*twod.Pos{start}
+
+	for len(wave) > 0 {
+		distance++
+		next := make([]*twod.Pos, 0)
+		for _, from := range wave {
+			for _, cand := range from.Follow(validFunc, connections[grid[from.Row][from.Col:from.Col+1]]) {
+				if dist[cand.Row][cand.Col] < 0 {
+					dist[cand.Row][cand.Col] = distance
+					maxSetDist = mathaid.Max(maxSetDist, distance)
+					next = append(next, cand.Clone())
+				}
+			}
+		}
+		wave = next
+	}
+
+	return maxSetDist
+}
+
+func isInsideShape(r, c int, grid []string) bool {
+	char := grid[r][c : c+1]
+	if char == "|" || char == "J" || char == "L" || char == "S" {
+		return true
+	}
+	return false
+}
+
+// Find the tiles that are NOT part of the loop (from part 1)
+// a tile is inside if it has an odd number of vertical, J or L next to them (S is a J in my input).
+func findInsides(grid []string, dist [][]int) []*twod.Pos {
+	insides := make([]*twod.Pos, 0)
+	for r, row := range dist {
+		insideShapes := 0
+		for c := range row {
+			if dist[r][c] >= 0 {
+				// part of the loop
+				if isInsideShape(r, c, grid) {
+					insideShapes++
+				}
+				continue
+			}
+			if insideShapes%2 == 1 {
+				insides = append(insides, twod.NewPos(r, c))
+			}
+		}
+	}
+	return insides
+}
+
+// loopRoute walks the loop from the start, after findFurthest has marked
+// the tiles on it.
+func loopRoute(grid []string, dist [][]int) []*twod.Pos {
+	validFunc := func(p *twod.Pos) bool {
+		return p.Row >= 0 && p.Col >= 0 &&
+			p.Row < len(grid) && p.Col < len(grid[0]) && dist[p.Row][p.Col] >= 0
+	}
+
+	start := findStart(grid)
+	seen := map[twod.Pos]bool{*start: true}
+	route := []*twod.Pos{start}
+	for at := start; ; {
+		var next *twod.Pos
+		for _, cand := range at.Follow(validFunc, connections[grid[at.Row][at.Col:at.Col+1]]) {
+			if !seen[*cand] {
+				next = cand
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		seen[*next] = true
+		route = append(route, next)
+		at = next
+	}
+	return append(route, start)
+}
+
+// render draws the pipes with the loop traced over them, the tiles inside
+// the loop and the furthest point on it.
+func render(grid []string, dist [][]int, furthest int, insides []*twod.Pos) *twod.Canvas {
+	c := twod.NewCanvas(len(grid), len(grid[0]))
+	c.FillFunc(func(p *twod.Pos) color.Color {
+		switch {
+		case dist[p.Row][p.Col] >= 0:
+			return color.RGBA{0x40, 0x40, 0x40, 0xff}
+		case grid[p.Row][p.Col] != '.':
+			return color.RGBA{0xc0, 0xc0, 0xc0, 0xff}
+		}
+		return nil
+	})
+	for _, p := range insides {
+		c.Fill(p, color.RGBA{0x33, 0xaa, 0x33, 0xff})
+	}
+	route := loopRoute(grid, dist)
+	c.Path(route, color.RGBA{0xff, 0x33, 0x33, 0xff})
+	c.Label(route[0], "S", color.RGBA{0x33, 0x33, 0xff, 0xff})
+	for r, row := range dist {
+		for col, d := range row {
+			if d == furthest {
+				c.Label(twod.NewPos(r, col), fmt.Sprint(d), color.RGBA{0x33, 0x33, 0xff, 0xff})
+			}
+		}
+	}
+	return c
+}
+
+func parse(ctx context.Context, r io.Reader) ([]string, [][]int, error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
+
+	grid := make([]string, 0)
+	dist := make([][]int, 0)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		grid = append(grid, line)
+		row := make([]int, len(line))
+		for i := range row {
+			row[i] = -1
+		}
+		dist = append(dist, row)
+	}
+	log.Debugw("loaded grid", "grid", grid, "dist", dist)
+	return grid, dist, scanner.Err()
+}
+
+// Part1 finds the point in the loop furthest from the start.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	grid, dist, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	furthest := findFurthest(grid, dist)
+	if rend := twod.RendererFromContext(ctx); rend.Enabled() {
+		rend.Add(render(grid, dist, furthest, nil))
+	}
+	return furthest, nil
+}
+
+// Part2 counts the tiles enclosed by the loop.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	grid, dist, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	// Walking the loop marks which tiles are part of it.
+	furthest := findFurthest(grid, dist)
+	insides := findInsides(grid, dist)
+	if rend := twod.RendererFromContext(ctx); rend.Enabled() {
+		rend.Add(render(grid, dist, furthest, insides))
+	}
+	return len(insides), nil
+}
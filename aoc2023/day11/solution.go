@@ -1,17 +1,22 @@
-package main
+package day11
 
 import (
 	"bufio"
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/adventofcode/pkg/twod"
 	"gonum.org/v1/gonum/stat/combin"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 11, Part1: Part1, Part2: Part2})
+}
+
 type Grid [][]string
 
 func (g Grid) String() string {
@@ -87,11 +92,9 @@ func expand(factor int, points []*twod.Pos, emptyRows, emptyCols []int) []*twod.
 	return newPoints
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+func parse(ctx context.Context, r io.Reader) (Grid, error) {
 	log := logging.FromContext(ctx)
-
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := bufio.NewScanner(r)
 
 	grid := make(Grid, 0)
 	for scanner.Scan() {
@@ -104,6 +107,29 @@ func main() {
 		grid = append(grid, chars)
 	}
 	log.Debugw("loaded", "grid", grid)
+	return grid, scanner.Err()
+}
+
+// Part1 sums the distances between galaxies with empty space doubled.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	grid, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return distances(ctx, grid, 1), nil
+}
+
+// Part2 makes empty space a million times larger.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	grid, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return distances(ctx, grid, 999999), nil
+}
+
+func distances(ctx context.Context, grid Grid, factor int) int64 {
+	log := logging.FromContext(ctx)
 
 	points := grid.Points()
 	log.Debugw("found points", "points", points)
@@ -111,27 +137,15 @@ func main() {
 	emptyRows := grid.EmptyRows()
 	emptyCols := grid.EmptyCols()
 
-	part1Points := expand(1, points, emptyRows, emptyCols)
-	log.Debugw("found points", "points", part1Points)
+	expanded := expand(factor, points, emptyRows, emptyCols)
+	log.Debugw("found points", "points", expanded)
 
-	pairs := combin.Combinations(len(part1Points), 2)
+	pairs := combin.Combinations(len(expanded), 2)
 	log.Debug("calculated pairs", "pairs", pairs)
 
-	allDist := 0
-	for _, pair := range pairs {
-		allDist += part1Points[pair[0]].Dist(part1Points[pair[1]])
-	}
-	log.Infow("answer", "part1", allDist)
-
-	part2Points := expand(999999, points, emptyRows, emptyCols)
-	log.Debugw("found points", "part2points", part2Points)
-	p2answer := int64(0)
+	allDist := int64(0)
 	for _, pair := range pairs {
-		p2answer += int64(part2Points[pair[0]].Dist(part2Points[pair[1]]))
-	}
-	log.Infow("answer", "part2", p2answer)
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
+		allDist += int64(expanded[pair[0]].Dist(expanded[pair[1]]))
 	}
+	return allDist
 }
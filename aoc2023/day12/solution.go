@@ -0,0 +1,149 @@
+package day12
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/memo"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/go-functional/slice"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 12, Part1: Part1, Part2: Part2})
+}
+
+func parse(r io.Reader) ([]string, [][]int, error) {
+	scanner := bufio.NewScanner(r)
+
+	segments := make([]string, 0)
+	groupings := make([][]int, 0)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		parts := strings.Split(line, " ")
+		segments = append(segments, parts[0])
+		groupings = append(groupings, slice.Map[string, int](strings.Split(parts[1], ","), func(s string) int {
+			i, err := strconv.Atoi(s)
+			if err != nil {
+				panic(err)
+			}
+			return i
+		}))
+	}
+	return segments, groupings, scanner.Err()
+}
+
+// Part1 counts the possible arrangements of each row.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	segments, groupings, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	part1 := 0
+	for i, s := range segments {
+		matches := findMatches(newCache(), s, groupings[i])
+		part1 += matches
+		log.Debugw("line", "matches", matches, "segments", s, "groupings", groupings[i])
+	}
+	return part1, nil
+}
+
+// Part2 unfolds each row to five copies.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	segments, groupings, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	part2 := 0
+	for i, s := range segments {
+		g := groupings[i]
+		// Expand input for part 2
+		xGroupings := append(append(append(append(g, g...), g...), g...), g...)
+		xSegments := strings.Join([]string{s, s, s, s, s}, "?")
+		matches := findMatches(newCache(), xSegments, g)
+		log.Debugw("line", "matches", matches, "segments", xSegments, "groupings", xGroupings)
+		part2 += matches
+	}
+	return part2, nil
+}
+
+// matchKey identifies a call to findMatches. The groupings are always a
+// suffix of the row's groupings, so how many are left is enough to key on.
+type matchKey = memo.Pair[string, int]
+
+func newCache() *memo.Cache[matchKey, int] {
+	return memo.New[matchKey, int](memo.Options{})
+}
+
+// findMatches counts the ways the segments can be filled in to match the
+// groupings, remembering the answers for each suffix in the cache.
+func findMatches(cache *memo.Cache[matchKey, int], segments string, groupings []int) int {
+	key := matchKey{A: segments, B: len(groupings)}
+	return cache.Do(key, func() int { return countMatches(cache, segments, groupings) })
+}
+
+func countMatches(cache *memo.Cache[matchKey, int], segments string, groupings []int) int {
+	// nothing left to match
+	if len(groupings) == 0 {
+		// but there are for sure # in the tail, so not a match
+		if strings.Contains(segments, "#") {
+			return 0
+		}
+		return 1
+	}
+
+	// nothing left in the input, but still groups to match
+	if len(segments) == 0 {
+		return 0
+	}
+
+	// Consume any .
+	if strings.HasPrefix(segments, ".") {
+		segments = strings.TrimLeft(segments, ".")
+		return findMatches(cache, segments, groupings)
+	}
+
+	// Test both options for a ?, this is the only branch.
+	if strings.HasPrefix(segments, "?") {
+		// first one is a '.', but would get stripped out anyway
+		return findMatches(cache, segments[1:], groupings) + findMatches(cache, "#"+segments[1:], groupings)
+	}
+
+	// must start w/ #
+	// not enough input left for the next grouping.
+	if len(segments) < groupings[0] {
+		return 0
+	}
+
+	// cannot fit the required length of the next group.
+	if strings.Contains(segments[0:groupings[0]], ".") {
+		return 0
+	}
+
+	// if more than one group, make sure there is a space after this one is consumed.
+	if len(groupings) > 1 {
+		if len(segments) < groupings[0]+1 || segments[groupings[0]:groupings[0]+1] == "#" {
+			return 0 //cannot go right into another sequence
+		}
+		segments = segments[groupings[0]+1:]
+		groupings = groupings[1:]
+		return findMatches(cache, segments, groupings)
+	}
+
+	// consume last group.
+	segments = segments[groupings[0]:]
+	groupings = groupings[1:]
+	return findMatches(cache, segments, groupings)
+}
@@ -1,14 +1,19 @@
-package main
+package day13
 
 import (
 	"bufio"
 	"context"
-	"os"
+	"io"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 13, Part1: Part1, Part2: Part2})
+}
+
 type Grid [][]string
 
 func (g Grid) String() string {
@@ -35,7 +40,7 @@ func opposite(s string) string {
 	}
 	return "."
 }
-func (g Grid) SmudgeValue(cannot int) int {
+func (g Grid) SmudgeValue(ctx context.Context, cannot int) int {
 	skipH := -1
 	skipV := -1
 	if cannot >= 100 {
@@ -49,7 +54,7 @@ func (g Grid) SmudgeValue(cannot int) int {
 	for r := 0; r < len(g); r++ {
 		for c := 0; c < len(g[r]); c++ {
 			g[r][c] = opposite(g[r][c])
-			if hm := g.HorizontalMirror(skipH); hm > 0 {
+			if hm := g.HorizontalMirror(ctx, skipH); hm > 0 {
 				return hm * 100
 			}
 			g[r][c] = opposite(g[r][c])
@@ -61,7 +66,7 @@ func (g Grid) SmudgeValue(cannot int) int {
 	for r := 0; r < len(ng); r++ {
 		for c := 0; c < len(ng[r]); c++ {
 			ng[r][c] = opposite(ng[r][c])
-			if hm := ng.HorizontalMirror(skipV); hm > 0 {
+			if hm := ng.HorizontalMirror(ctx, skipV); hm > 0 {
 				return hm
 			}
 			ng[r][c] = opposite(ng[r][c])
@@ -86,8 +91,8 @@ func (g Grid) Transpose() Grid {
 
 // find the rows above a horizontal mirror, -1 if can't be found.
 // at least one (top or bottom) must be fully covered.
-func (g Grid) HorizontalMirror(skip int) int {
-	log := logging.DefaultLogger()
+func (g Grid) HorizontalMirror(ctx context.Context, skip int) int {
+	log := logging.FromContext(ctx)
 	// r is "before" row 1 (Between 0 / 1)
 	for r := 1; r < len(g); r++ {
 		if r == skip {
@@ -113,11 +118,8 @@ func (g Grid) HorizontalMirror(skip int) int {
 	return -1
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
-	log := logging.FromContext(ctx)
-
-	scanner := bufio.NewScanner(os.Stdin)
+func parse(r io.Reader) ([]Grid, error) {
+	scanner := bufio.NewScanner(r)
 
 	grids := make([]Grid, 0)
 	cur := make(Grid, 0)
@@ -135,38 +137,61 @@ func main() {
 	if len(cur) > 0 {
 		grids = append(grids, cur)
 	}
+	return grids, scanner.Err()
+}
 
-	part1 := 0
-	values := make([]int, 0)
+// mirrorValues finds the original reflection line in each grid.
+func mirrorValues(ctx context.Context, grids []Grid) []int {
+	log := logging.FromContext(ctx)
+
+	values := make([]int, 0, len(grids))
 	for i, g := range grids {
 
 		gV := g.Transpose()
-		if vert := gV.HorizontalMirror(-1); vert > 0 {
+		if vert := gV.HorizontalMirror(ctx, -1); vert > 0 {
 			log.Infow("vertical", "mirror", i, "toLeft", vert)
 			values = append(values, vert)
-			part1 += vert
 			continue
 		}
 		// must be horizontal
-		horiz := g.HorizontalMirror(-1)
+		horiz := g.HorizontalMirror(ctx, -1)
 		log.Infow("horizontal", "mirror", i, "above", horiz)
 		if horiz < 0 {
 			panic("didn't find a mirror " + g.String())
 		}
 		values = append(values, 100*horiz)
-		part1 += (100 * horiz)
 	}
-	log.Infow("answer", "part1", part1)
+	return values
+}
 
+// Part1 summarizes the reflection lines.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	grids, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	part1 := 0
+	for _, v := range mirrorValues(ctx, grids) {
+		part1 += v
+	}
+	return part1, nil
+}
+
+// Part2 summarizes the new reflection lines once the smudges are fixed.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	grids, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	values := mirrorValues(ctx, grids)
 	part2 := 0
 	for i, g := range grids {
-		sv := g.SmudgeValue(values[i])
+		sv := g.SmudgeValue(ctx, values[i])
 		log.Infow("part2", "mirror", i, "value", sv)
 		part2 += sv
 	}
-	log.Infow("answer", "part2", part2)
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
-	}
+	return part2, nil
 }
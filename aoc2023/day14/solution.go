@@ -1,12 +1,19 @@
-package main
+package day14
 
 import (
 	"bufio"
-	"fmt"
-	"os"
+	"context"
+	"io"
 	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 14, Part1: Part1, Part2: Part2})
+}
+
 type Grid [][]string
 
 func (g Grid) String() string {
@@ -106,38 +113,50 @@ func (g Grid) Weight() int {
 	return weight
 }
 
-func main() {
-	scanner := bufio.NewScanner(os.Stdin)
+func parse(r io.Reader) (Grid, error) {
+	scanner := bufio.NewScanner(r)
 
 	grid := make(Grid, 0)
-	grid2 := make(Grid, 0)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
 			continue
 		}
-		row := strings.Split(line, "")
-		grid = append(grid, row)
-		// second grid since first one gets a single north tilt
-		grid2 = append(grid2, row)
+		grid = append(grid, strings.Split(line, ""))
+	}
+	return grid, scanner.Err()
+}
+
+// Part1 is the load on the north beams after a single north tilt.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	grid, err := parse(r)
+	if err != nil {
+		return nil, err
 	}
 
 	grid.TiltNorth()
-	fmt.Printf("answer part 1: %v\n", grid.Weight())
+	return grid.Weight(), nil
+}
 
-	grid = nil
+// Part2 is the load after a billion spin cycles.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	grid, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
 
 	cycles := make(map[string]int)
-	cycles[grid2.String()] = 0
+	cycles[grid.String()] = 0
 
 	initial := 0
 	cycleWeight := 0
 	// assume there will be a cycle before 10k
 	for i := 1; i <= 10000; i++ {
-		grid2.Cycle()
-		key := grid2.String()
+		grid.Cycle()
+		key := grid.String()
 		if v, ok := cycles[key]; ok {
-			fmt.Printf("MATCH: %+v to %+v cycles\n", v, i)
+			log.Debugw("found cycle", "from", v, "to", i)
 			initial = v
 			cycleWeight = i - v
 			break
@@ -148,8 +167,8 @@ func main() {
 	// and the mod of the cycle length is how many cycles to 1B.
 	toDo := (1_000_000_000 - initial) % cycleWeight
 	for i := 0; i < toDo; i++ {
-		grid2.Cycle()
+		grid.Cycle()
 	}
 
-	fmt.Printf("part2: %+v\n", grid2.Weight())
+	return grid.Weight(), nil
 }
@@ -1,16 +1,21 @@
-package main
+package day15
 
 import (
-	"bufio"
 	"context"
-	"os"
+	"io"
 	"strconv"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/go-functional/slice"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 15, Part1: Part1, Part2: Part2})
+}
+
 type Hasher string
 
 func (h Hasher) Hash() int {
@@ -48,34 +53,50 @@ type Lens struct {
 	Value int
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+// steps splits the initialization sequence, one long line where newlines
+// are ignored, into its steps.
+func steps(ctx context.Context, r io.Reader) ([]string, error) {
 	log := logging.FromContext(ctx)
 
-	scanner := bufio.NewScanner(os.Stdin)
-	var line string
-	for scanner.Scan() {
-		line = scanner.Text()
-		if line == "" {
-			continue
-		}
+	input, err := parse.Bytes(r)
+	if err != nil {
+		return nil, err
 	}
+	line := strings.NewReplacer("\n", "", "\r", "").Replace(string(input))
 	log.Infow("line", "line", line)
+	return strings.Split(line, ","), nil
+}
 
-	parts := strings.Split(line, ",")
-	tot := 0
-	boxes := make([][]Lens, 256)
-	for i := range boxes {
-		boxes[i] = make([]Lens, 0)
+// Part1 sums the hash of each step.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	parts, err := steps(ctx, r)
+	if err != nil {
+		return nil, err
 	}
 
+	tot := 0
 	for _, p := range parts {
 		h := Hasher(p)
 		hash := h.Hash()
 		log.Debugw("hashing", "in", h, "value", hash)
 		tot += hash
 	}
-	log.Infow("answer", "part1", tot)
+	return tot, nil
+}
+
+// Part2 places the lenses in the boxes and sums the focusing power.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	parts, err := steps(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	boxes := make([][]Lens, 256)
+	for i := range boxes {
+		boxes[i] = make([]Lens, 0)
+	}
 
 	for _, p := range parts {
 		lens := ToLens(p)
@@ -115,5 +136,5 @@ func main() {
 			part2 += ((i + 1) * ((li + 1) * l.Value))
 		}
 	}
-	log.Infow("answer", "part2", part2)
+	return part2, nil
 }
@@ -1,17 +1,25 @@
-package main
+package day16
 
 import (
 	"bufio"
 	"context"
 	"fmt"
-	"os"
+	"image/color"
+	"io"
 	"strings"
 
-	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/anim"
+	"github.com/mikehelmick/adventofcode/pkg/parallel"
+	"github.com/mikehelmick/adventofcode/pkg/set"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/adventofcode/pkg/twod"
 	"github.com/mikehelmick/go-functional/slice"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 16, Part1: Part1, Part2: Part2})
+}
+
 var (
 	// helpers for reflections.
 	leftMirror = map[string]string{
@@ -24,6 +32,24 @@ var (
 
 type Grid [][]string
 
+// frameRunes are the runes written by Render, indexes into palette.
+const frameRunes = ".#/\\|-RULD"
+
+// palette colors the empty tiles, energized tiles, mirrors and splitters
+// and then the beam in recorded frames.
+var palette = color.Palette{
+	color.Black,
+	color.RGBA{0x99, 0x66, 0x00, 0xff},
+	color.RGBA{0x60, 0x60, 0x60, 0xff},
+	color.RGBA{0x60, 0x60, 0x60, 0xff},
+	color.RGBA{0x60, 0x60, 0x60, 0xff},
+	color.RGBA{0x60, 0x60, 0x60, 0xff},
+	color.RGBA{0xff, 0xff, 0x33, 0xff},
+	color.RGBA{0xff, 0xff, 0x33, 0xff},
+	color.RGBA{0xff, 0xff, 0x33, 0xff},
+	color.RGBA{0xff, 0xff, 0x33, 0xff},
+}
+
 type Light struct {
 	Position  *twod.Pos
 	Direction string
@@ -46,46 +72,57 @@ func (l *Light) Move() *Light {
 	}
 }
 
-func Print(g Grid, wf []*Light, e map[string]bool) {
+// Render draws the grid with the wave front of the beam and the energized
+// tiles.
+func Render(g Grid, wf []*Light, e set.Set[twod.Pos]) string {
 	m := make(map[string]*Light)
 	for _, k := range wf {
 		m[k.Position.String()] = k
 	}
 
+	b := strings.Builder{}
 	for r, row := range g {
 		for c, col := range row {
 			key := fmt.Sprintf("{%v,%v}", r, c)
 			if l, ok := m[key]; ok {
-				fmt.Printf(l.Direction)
-			} else if e[key] {
-				fmt.Printf("#")
+				b.WriteString(l.Direction)
+			} else if e.Contains(twod.Pos{Row: r, Col: c}) {
+				b.WriteString("#")
 			} else {
-				fmt.Print(col)
+				b.WriteString(col)
 			}
 		}
-		fmt.Printf("\n")
+		b.WriteString("\n")
 	}
+	return b.String()
+}
+
+func Print(g Grid, wf []*Light, e set.Set[twod.Pos]) {
+	fmt.Print(Render(g, wf, e))
 	fmt.Printf("\n**********\n")
 }
 
-func shootLasers(g Grid, e map[string]bool, start *Light) {
+// shootLasers follows the beam from start, adding a frame to rec for each
+// step of the wave front.
+func shootLasers(g Grid, e set.Set[twod.Pos], start *Light, rec *anim.Recorder) {
 	isValid := func(l *Light) bool {
 		p := l.Position
 		return p.Row >= 0 && p.Col >= 0 && p.Row < len(g) && p.Col < len(g[0])
 	}
 
 	waveFront := []*Light{start}
-	// This is for termination, the energized map is just energized.
-	visitDirections := make(map[string]bool)
+	// This is for termination, the energized set is just energized.
+	visitDirections := set.New[string]()
 
 	for len(waveFront) > 0 {
-		// for cool animations, uncomment next line.
-		//Print(g, waveFront, e)
+		if rec.Enabled() {
+			rec.Add(anim.FromText(Render(g, waveFront, e), frameRunes, palette))
+		}
 		nextWave := make([]*Light, 0)
 		for _, l := range waveFront {
 			// mark space visited
-			e[l.Position.String()] = true
-			visitDirections[l.Key()] = true
+			e.Add(*l.Position)
+			visitDirections.Add(l.Key())
 			space := g[l.Position.Row][l.Position.Col]
 			switch space {
 			case ".":
@@ -120,19 +157,15 @@ func shootLasers(g Grid, e map[string]bool, start *Light) {
 		// and filter out lights that we've seen before (Same space & direction)
 		waveFront = slice.Filter(slice.Filter(nextWave, isValid),
 			func(l *Light) bool {
-				return !visitDirections[l.Key()]
+				return !visitDirections.Contains(l.Key())
 			})
 	}
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
-	log := logging.FromContext(ctx)
-
-	scanner := bufio.NewScanner(os.Stdin)
+func parse(r io.Reader) (Grid, error) {
+	scanner := bufio.NewScanner(r)
 
 	g := make(Grid, 0)
-	e := make(map[string]bool)
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
@@ -141,10 +174,27 @@ func main() {
 		row := strings.Split(line, "")
 		g = append(g, row)
 	}
+	return g, scanner.Err()
+}
 
-	shootLasers(g, e, &Light{twod.NewPos(0, 0), twod.RIGHT})
-	part1 := len(e)
-	fmt.Printf("part1: %v\n", part1)
+// Part1 counts the tiles energized by a beam entering the top left.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	g, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	e := set.New[twod.Pos]()
+	shootLasers(g, e, &Light{twod.NewPos(0, 0), twod.RIGHT}, anim.FromContext(ctx))
+	return e.Len(), nil
+}
+
+// Part2 tries every edge entry for the beam, in parallel.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	g, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
 
 	starting := make([]*Light, 0)
 	for r := 0; r < len(g); r++ {
@@ -165,15 +215,9 @@ func main() {
 			starting = append(starting, &Light{twod.NewPos(r, len(g[r])-1), twod.LEFT})
 		}
 	}
-	part2 := 0
-	for _, s := range starting {
-		e := make(map[string]bool)
-		shootLasers(g, e, s)
-		part2 = max(part2, len(e))
-	}
-	fmt.Printf("part2: %v\n", part2)
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
-	}
+	return parallel.Reduce(ctx, parallel.Options{}, starting, 0, func(_ context.Context, s *Light) (int, error) {
+		e := set.New[twod.Pos]()
+		shootLasers(g, e, s, nil)
+		return e.Len(), nil
+	}, func(a, b int) int { return max(a, b) })
 }
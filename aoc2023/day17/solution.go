@@ -1,19 +1,40 @@
-package main
+package day17
 
 import (
 	"bufio"
 	"container/heap"
+	"context"
 	"fmt"
-	"os"
+	"image/color"
+	"io"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/adventofcode/pkg/twod"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 17, Part1: Part1, Part2: Part2})
+}
+
 type Entry struct {
 	P      Path
 	Weight int
+	// Prev is the entry this one was reached from, nil at the start.
+	Prev *Entry
+}
+
+// Route returns the positions from the start to the entry. Each move is in
+// a straight line, so they are the turns of the path.
+func (e *Entry) Route() []*twod.Pos {
+	route := make([]*twod.Pos, 0)
+	for ; e != nil; e = e.Prev {
+		route = append(route, e.P.Pos)
+	}
+	slices.Reverse(route)
+	return route
 }
 
 type PQ []Entry
@@ -48,7 +69,9 @@ func (p *Path) String() string {
 	return fmt.Sprintf("%v-%v", p.Pos, p.Dir)
 }
 
-func search(g Grid, minDir int, maxDir int) int {
+// search finds the least heat loss from the top left to the bottom right,
+// and the route that has it.
+func search(g Grid, minDir int, maxDir int) (int, *Entry) {
 	isValid := func(p *twod.Pos) bool {
 		return p.Row >= 0 && p.Col >= 0 && p.Row < len(g) && p.Col < len(g[0])
 	}
@@ -64,7 +87,7 @@ func search(g Grid, minDir int, maxDir int) int {
 		entry := heap.Pop(queue).(Entry)
 
 		if entry.P.Pos.Equals(end) {
-			return entry.Weight
+			return entry.Weight, &entry
 		}
 		if visited[entry.P.String()] {
 			continue
@@ -92,18 +115,46 @@ func search(g Grid, minDir int, maxDir int) int {
 						}
 						hl += g[nextPos.Row][nextPos.Col]
 					}
-					heap.Push(queue, Entry{P: Path{nextPos, d}, Weight: heat + hl})
+					heap.Push(queue, Entry{P: Path{nextPos, d}, Weight: heat + hl, Prev: &entry})
 				}
 			}
 		}
 	}
-	return -1
+	return -1, nil
 }
 
 type Grid [][]int
 
-func main() {
-	scanner := bufio.NewScanner(os.Stdin)
+// Render draws the heat loss of each block with the route through them.
+func (g Grid) Render(heat int, route *Entry) *twod.Canvas {
+	c := twod.NewCanvas(len(g), len(g[0]))
+	c.FillFunc(func(p *twod.Pos) color.Color {
+		return twod.Heat(float64(g[p.Row][p.Col]-1) / 8)
+	})
+	if route != nil {
+		points := route.Route()
+		c.Path(points, color.White)
+		c.Label(points[0], "start", color.White)
+		c.Label(points[len(points)-1], fmt.Sprintf("heat loss %d", heat), color.White)
+	}
+	return c
+}
+
+// solve searches for the route and draws it when rendering.
+func solve(ctx context.Context, r io.Reader, minDir int, maxDir int) (any, error) {
+	g, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	heat, route := search(g, minDir, maxDir)
+	if rend := twod.RendererFromContext(ctx); rend.Enabled() {
+		rend.Add(g.Render(heat, route))
+	}
+	return heat, nil
+}
+
+func parse(r io.Reader) (Grid, error) {
+	scanner := bufio.NewScanner(r)
 
 	g := make(Grid, 0)
 	for scanner.Scan() {
@@ -122,10 +173,15 @@ func main() {
 		}
 		g = append(g, row)
 	}
+	return g, scanner.Err()
+}
 
-	minLoss := search(g, 1, 3)
-	fmt.Printf("part1 %+v\n", minLoss)
+// Part1 finds the least heat loss moving at most 3 blocks in a line.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	return solve(ctx, r, 1, 3)
+}
 
-	part2 := search(g, 4, 10)
-	fmt.Printf("part2 %+v\n", part2)
+// Part2 uses the ultra crucibles, which move between 4 and 10 blocks.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	return solve(ctx, r, 4, 10)
 }
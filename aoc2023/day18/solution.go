@@ -1,18 +1,22 @@
-package main
+package day18
 
 import (
 	"bufio"
 	"context"
-	"fmt"
 	"image"
+	"io"
 	"math/big"
-	"os"
 	"strconv"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 18, Part1: Part1, Part2: Part2})
+}
+
 type Plan struct {
 	Dir   string
 	Amt   int
@@ -51,11 +55,8 @@ var dir = map[string]image.Point{
 	"L": {0, -1},
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
-	log := logging.FromContext(ctx)
-
-	scanner := bufio.NewScanner(os.Stdin)
+func parse(r io.Reader) ([]Plan, error) {
+	scanner := bufio.NewScanner(r)
 
 	plan := make([]Plan, 0)
 	for scanner.Scan() {
@@ -70,7 +71,15 @@ func main() {
 		}
 		plan = append(plan, Plan{parts[0], amt, strings.TrimSuffix(strings.TrimPrefix(parts[2], "(#"), ")")})
 	}
-	//fmt.Printf("%+v\n", plan)
+	return plan, scanner.Err()
+}
+
+// Part1 digs the lagoon from the plan directions.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	plan, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
 
 	digger := image.Point{0, 0}
 	points := make([]image.Point, len(plan))
@@ -81,27 +90,27 @@ func main() {
 		intPoints += int64(p.Amt)
 	}
 	inside := shoelace(points)
-	//part1 := inside.Add(inside, perimiter)
-	//fmt.Printf("part1: %+v\n", inside)
-	fmt.Printf("part1: %+v\n", prick(inside, intPoints))
-
-	// part 2 is... bigger
-	digger = image.Point{0, 0}
-	points = make([]image.Point, len(plan))
-	intPoints = int64(1)
+	return prick(inside, intPoints), nil
+}
+
+// Part2 is... bigger, the real plan is hidden in the colors.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	plan, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	digger := image.Point{0, 0}
+	points := make([]image.Point, len(plan))
+	intPoints := int64(1)
 	for _, p := range plan {
-		//fmt.Printf("digging: %v %v\n", p.Distance(), p.Direction())
+		log.Debugw("digging", "distance", p.Distance(), "direction", p.Direction())
 		digger = digger.Add(dir[p.Direction()].Mul(p.Distance()))
 		points = append(points, digger)
 		intPoints += int64(p.Distance())
 	}
-	part2 := shoelace(points)
-	//fmt.Printf("part2: %+v\n", part2)
-	fmt.Printf("part2: %+v\n", prick(part2, intPoints))
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
-	}
+	return prick(shoelace(points), intPoints), nil
 }
 
 // prick's theorem
@@ -0,0 +1,333 @@
+package day19
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/interval"
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+func init() {
+	solver.Register(&solver.Day{
+		Year:  2023,
+		Day:   19,
+		Part1: Part1,
+		Part2: Part2,
+		Alternates: map[int][]solver.Impl{
+			1: {{Name: "ranges", Func: Part1Ranges}},
+		},
+	})
+}
+
+// For part 1
+type Part struct {
+	Values map[string]int
+}
+
+func (p *Part) Sum() int {
+	s := 0
+	for _, v := range p.Values {
+		s += v
+	}
+	return s
+}
+
+func NewPart(s string) *Part {
+	p := Part{
+		Values: make(map[string]int),
+	}
+	s = strings.TrimPrefix(s, "{")
+	s = strings.TrimSuffix(s, "}")
+
+	parts := strings.Split(s, ",")
+	for _, sub := range parts {
+		nval := strings.Split(sub, "=")
+		val, err := strconv.Atoi(nval[1])
+		if err != nil {
+			panic(err)
+		}
+		p.Values[nval[0]] = val
+	}
+	return &p
+}
+
+// This was a very clever optimization for part 1, that did
+// nothing for part 2. oh well.
+type RuleFn func(*Part) (bool, string)
+
+type Workflow struct {
+	// Line is where the workflow is in the input, counted from one.
+	Line       int
+	Name       string
+	Rules      []RuleFn
+	RuleString []string
+}
+
+func (wf *Workflow) Sort(p *Part) string {
+	for _, r := range wf.Rules {
+		match, dest := r(p)
+		if match {
+			return dest
+		}
+	}
+	panic("part did not match any rules")
+}
+
+func writeGtLtFun(r string, op string) RuleFn {
+	exAndDest := strings.Split(r, ":")
+	dest := exAndDest[1]
+	operands := strings.Split(exAndDest[0], op)
+	val, err := strconv.Atoi(operands[1])
+	xmas := operands[0]
+	if err != nil {
+		panic(err)
+	}
+	if op == ">" {
+		return func(p *Part) (bool, string) {
+			if p.Values[xmas] > val {
+				return true, dest
+			}
+			return false, ""
+		}
+	}
+	return func(p *Part) (bool, string) {
+		if p.Values[xmas] < val {
+			return true, dest
+		}
+		return false, ""
+	}
+}
+
+func NewWorkflow(s string) *Workflow {
+	nameAndRule := strings.Split(s, "{")
+	wf := Workflow{
+		Name:       nameAndRule[0],
+		Rules:      make([]RuleFn, 0),
+		RuleString: make([]string, 0), // preserve for part 2
+	}
+
+	parts := strings.Split(strings.TrimSuffix(nameAndRule[1], "}"), ",")
+	for _, r := range parts {
+		wf.RuleString = append(wf.RuleString, r)
+		var rf RuleFn
+		if strings.Contains(r, ">") {
+			rf = writeGtLtFun(r, ">")
+		} else if strings.Contains(r, "<") {
+			rf = writeGtLtFun(r, "<")
+		} else {
+			// write static fun
+			rf = func(p *Part) (bool, string) {
+				return true, r
+			}
+		}
+		wf.Rules = append(wf.Rules, rf)
+	}
+
+	return &wf
+}
+
+func parse(ctx context.Context, r io.Reader) (map[string]*Workflow, []*Part, error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
+
+	workflows := make(map[string]*Workflow)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		wf := NewWorkflow(line)
+		wf.Line = n
+		log.Debugw("loaded", "workflow", wf.Name, "rules", len(wf.Rules))
+		workflows[wf.Name] = wf
+	}
+	log.Debugw("loaded workflows", "n", len(workflows))
+
+	parts := make([]*Part, 0)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		parts = append(parts, NewPart(line))
+	}
+	log.Debugw("loaded parts", "n", len(parts))
+	return workflows, parts, scanner.Err()
+}
+
+// Part1 sums the ratings of the parts that are accepted.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	workflows, parts, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make(map[string][]*Part)
+	buckets["in"] = parts
+
+	// progressively process every bucket of parts through the assigned workflow
+	accepted := make([]*Part, 0)
+	for len(buckets) > 0 {
+		next := make(map[string][]*Part)
+		for wfName, parts := range buckets {
+			if wfName == "R" {
+				continue
+			}
+			if wfName == "A" {
+				for _, p := range parts {
+					p := p
+					accepted = append(accepted, p)
+				}
+				continue
+			}
+
+			for _, part := range parts {
+				part := part
+				dest := workflows[wfName].Sort(part)
+				if next[dest] == nil {
+					next[dest] = make([]*Part, 0)
+				}
+				next[dest] = append(next[dest], part)
+			}
+		}
+		buckets = next
+	}
+
+	part1 := 0
+	for _, a := range accepted {
+		part1 += a.Sum()
+	}
+	return part1, nil
+}
+
+// Ratings is the key to part 2, a box of part ratings.
+// We start with XMAS all 1-4000
+// and the box will be split depending on rules.
+// At the end, we can sum all the parts in the accepted boxes.
+type Ratings = interval.Box[string, int]
+
+func allRatings() Ratings {
+	all := interval.Closed(1, 4000)
+	b := interval.NewBox[string, int]()
+	for _, k := range []string{"x", "m", "a", "s"} {
+		b = b.With(k, all)
+	}
+	return b
+}
+
+// condition splits a rule like "x>2001:dest" into its parts, ok is false
+// for a rule that always sends parts to dest.
+func condition(rule string) (key string, cmp interval.Cmp, val int, dest string, ok bool) {
+	cond, dest, found := strings.Cut(rule, ":")
+	i := strings.IndexAny(cond, "<>")
+	if !found || i < 0 {
+		return "", 0, 0, rule, false
+	}
+	cmp, err := interval.ParseCmp(cond[i : i+1])
+	if err != nil {
+		return "", 0, 0, rule, false
+	}
+	// NewWorkflow has already checked the value.
+	val, _ = strconv.Atoi(cond[i+1:])
+	return cond[:i], cmp, val, dest, true
+}
+
+// acceptedRanges splits the full range of ratings through the workflows and
+// returns the boxes that end up accepted. Rules on a rating other than
+// x, m, a or s are an error.
+func acceptedRanges(ctx context.Context, workflows map[string]*Workflow) ([]Ratings, error) {
+	log := logging.FromContext(ctx)
+
+	accepted := make([]Ratings, 0)
+
+	buckets := map[string][]Ratings{"in": {allRatings()}}
+	for len(buckets) > 0 {
+		next := make(map[string][]Ratings)
+		log.Debugw("splitting", "buckets", buckets)
+
+		for wfName, boxes := range buckets {
+			if wfName == "R" {
+				log.Debugw("rejecting", "ranges", boxes)
+				continue
+			}
+			if wfName == "A" {
+				log.Debugw("accepting", "ranges", boxes)
+				accepted = append(accepted, boxes...)
+				continue
+			}
+
+			for _, box := range boxes {
+				wf := workflows[wfName]
+				for _, rule := range wf.RuleString {
+					key, cmp, val, dest, ok := condition(rule)
+					if !ok {
+						next[dest] = append(next[dest], box)
+						break
+					}
+					match, rest, ok := box.Split(key, cmp, val)
+					if !ok {
+						return nil, fmt.Errorf("line %d: rule %q rates %q, want one of x m a s", wf.Line, rule, key)
+					}
+					if !match.Empty() {
+						next[dest] = append(next[dest], match)
+					}
+					if rest.Empty() {
+						break
+					}
+					box = rest
+				}
+			}
+		}
+		buckets = next
+	}
+
+	log.Debugw("ranges", "n", len(accepted))
+	return accepted, nil
+}
+
+// Part2 counts the combinations of ratings that would be accepted.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	workflows, _, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted, err := acceptedRanges(ctx, workflows)
+	if err != nil {
+		return nil, err
+	}
+	// The accepted boxes are disjoint, but the union doesn't rely on it.
+	return interval.UnionVolume(accepted).Int64(), nil
+}
+
+// Part1Ranges solves part 1 with the part 2 approach, checking each part
+// against the accepted ranges instead of running it through the workflows.
+func Part1Ranges(ctx context.Context, r io.Reader) (any, error) {
+	workflows, parts, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	accepted, err := acceptedRanges(ctx, workflows)
+	if err != nil {
+		return nil, err
+	}
+	part1 := 0
+	for _, p := range parts {
+		for _, a := range accepted {
+			if a.Contains(p.Values) {
+				part1 += p.Sum()
+				break
+			}
+		}
+	}
+	return part1, nil
+}
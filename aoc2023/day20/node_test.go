@@ -1,4 +1,4 @@
-package main
+package day20
 
 import "testing"
 
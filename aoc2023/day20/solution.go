@@ -1,17 +1,22 @@
-package main
+package day20
 
 import (
 	"bufio"
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"slices"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
 	"github.com/mikehelmick/adventofcode/pkg/mathaid"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 20, Part1: Part1, Part2: Part2})
+}
+
 type Signal int
 
 const (
@@ -154,11 +159,9 @@ type Send struct {
 	Pulse Signal
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+func parse(ctx context.Context, r io.Reader) (map[string]Module, error) {
 	log := logging.FromContext(ctx)
-
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := bufio.NewScanner(r)
 
 	modules := make(map[string]Module)
 	inputs := make(map[string][]string)
@@ -186,10 +189,20 @@ func main() {
 			}
 		}
 	}
-	log.Infow("loaded", "modules", len(modules))
+	log.Debugw("loaded", "modules", len(modules))
 	for name, mod := range modules {
 		log.Debugw("module", "name", name, "mod", mod)
 	}
+	return modules, scanner.Err()
+}
+
+// Part1 multiplies the low and high pulses sent over 1000 button presses.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	modules, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
 	lowSent := 0
 	highSent := 0
@@ -199,12 +212,16 @@ func main() {
 		highSent += r.HighSent
 	}
 
-	log.Infow("signals", "low", lowSent, "high", highSent)
-	part1 := lowSent * highSent
-	log.Infow("answer", "part1", part1)
+	log.Debugw("signals", "low", lowSent, "high", highSent)
+	return lowSent * highSent, nil
+}
 
-	for _, m := range modules {
-		m.Reset()
+// Part2 finds the fewest presses to send a low pulse to rx.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	modules, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
 	}
 
 	// Using some observations from input set.
@@ -217,7 +234,10 @@ func main() {
 			target = n
 		}
 	}
-	log.Infow("part2 target", "module", target)
+	if target == "" {
+		return nil, fmt.Errorf("no module sends to rx")
+	}
+	log.Debugw("part2 target", "module", target)
 	// then find all of the conjunctions that feed into target
 	cycles := map[string]int{}
 	for n, mod := range modules {
@@ -226,7 +246,7 @@ func main() {
 			report.From[n] = true
 		}
 	}
-	log.Infow("part2 cycles", "modules", report.From)
+	log.Debugw("part2 cycles", "modules", report.From)
 	report.Target = target
 
 	presses := 0
@@ -253,11 +273,10 @@ func main() {
 
 	cyclesAt := make([]int64, 0)
 	for m, v := range cycles {
-		log.Infow("cycles", "mod", m, "count", v)
+		log.Debugw("cycles", "mod", m, "count", v)
 		cyclesAt = append(cyclesAt, int64(v))
 	}
-	part2 := mathaid.LowestCommonMultiple(cyclesAt[0], cyclesAt[1], cyclesAt[2:]...)
-	log.Infow("answer", "part2", part2)
+	return mathaid.LowestCommonMultiple(cyclesAt[0], cyclesAt[1], cyclesAt[2:]...), nil
 }
 
 type Report struct {
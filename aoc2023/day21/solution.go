@@ -0,0 +1,141 @@
+package day21
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/adventofcode/pkg/twod"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 21, Part1: Part1, Part2: Part2})
+}
+
+type Grid [][]string
+
+func (g Grid) String() string {
+	b := strings.Builder{}
+	for _, row := range g {
+		b.WriteString(strings.Join(row, ""))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (g Grid) FindStart() *twod.Pos {
+	for r, row := range g {
+		for c, v := range row {
+			if v == "S" {
+				return twod.NewPos(r, c)
+			}
+		}
+	}
+	panic("no start")
+}
+
+func (g Grid) GetPoint(row int, col int) string {
+	row = row % len(g)
+	if row < 0 {
+		row = len(g) + row // add negative num
+	}
+	col = col % len(g[0])
+	if col < 0 {
+		col = len(g[0]) + col
+	}
+	return g[row][col]
+}
+
+// BFS counts the plots reachable in exactly 0, 1, ... steps steps from s,
+// the garden repeats forever when infinite is set.
+func (g Grid) BFS(ctx context.Context, s *twod.Pos, steps int, infinite bool) []int {
+	log := logging.FromContext(ctx)
+	isValid := func(p *twod.Pos) bool {
+		return p.Row >= 0 && p.Col >= 0 && p.Row < len(g) && p.Col < len(g[0])
+	}
+	if infinite {
+		isValid = func(p *twod.Pos) bool { return true }
+	}
+
+	counts := []int{1}
+	wave := []*twod.Pos{s}
+	for i := 0; i < steps && len(wave) > 0; i++ {
+		visited := make(map[twod.Pos]bool)
+		next := make([]*twod.Pos, 0)
+		for _, w := range wave {
+			for _, n := range w.Neighbors(isValid) {
+				if v := g.GetPoint(n.Row, n.Col); (v == "." || v == "S") && !visited[*n] {
+					next = append(next, n)
+					visited[*n] = true
+				}
+			}
+		}
+		wave = next
+		counts = append(counts, len(visited))
+		log.Debugw("wave", "steps", i+1, "count", len(visited))
+	}
+	return counts
+}
+
+func parse(r io.Reader) (Grid, error) {
+	scanner := bufio.NewScanner(r)
+
+	g := make(Grid, 0)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		row := strings.Split(line, "")
+		g = append(g, row)
+	}
+	return g, scanner.Err()
+}
+
+// Part1 counts the plots reachable in exactly 64 steps.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	g, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	counts := g.BFS(ctx, g.FindStart(), 64, false)
+	return counts[len(counts)-1], nil
+}
+
+// part2Steps is how far the elf walks in part 2.
+const part2Steps = 26501365
+
+// Part2 extrapolates the plots reachable over the infinite garden. In the
+// puzzle input the start is in the middle of a square garden with clear
+// paths to its edges, and the walk ends on the edge of a copy of the garden.
+// The reachable plots then grow quadratically each time the walk crosses
+// another copy, so three samples a garden apart fit the whole curve.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	g, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	size := len(g)
+	start := g.FindStart()
+	if len(g[0]) != size || start.Row != size/2 || start.Col != size/2 || part2Steps%size != size/2 {
+		return nil, fmt.Errorf("extrapolating needs a square garden with the start in the middle and %d steps ending on its edge, got %dx%d starting at %v",
+			part2Steps, size, len(g[0]), start)
+	}
+
+	counts := g.BFS(ctx, start, size/2+2*size, true)
+	a0, a1, a2 := counts[size/2], counts[size/2+size], counts[size/2+2*size]
+	return extrapolate(uint64(part2Steps/size), int64(a0), int64(a1), int64(a2)), nil
+}
+
+// extrapolate evaluates at goal the quadratic through a0, a1 and a2 at 0, 1
+// and 2.
+func extrapolate(goal uint64, a0, a1, a2 int64) uint64 {
+	b0 := uint64(a0)
+	b1 := uint64(a1 - a0)
+	b2 := uint64(a2 - a1)
+	return b0 + b1*goal + (goal*(goal-1)/2)*(b2-b1)
+}
@@ -1,17 +1,23 @@
-package main
+package day22
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"sort"
-	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/set"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/adventofcode/pkg/threed"
+	"github.com/mikehelmick/adventofcode/pkg/twod"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 22, Part1: Part1, Part2: Part2})
+}
+
 type Chamber [][][]int
 
 func (c Chamber) Print() {
@@ -44,27 +50,39 @@ type Brick struct {
 	RestsOn map[int]bool
 }
 
-func NewBrick(id int, l string) *Brick {
-	parts := strings.Split(l, "~")
-	b := &Brick{
+// NewBrick parses a brick written as its two corners, "x,y,z~x,y,z".
+func NewBrick(id int, l parse.Field) (*Brick, error) {
+	a, b, err := l.Cut("~")
+	if err != nil {
+		return nil, err
+	}
+	posA, err := threed.ParsePos(a.Text)
+	if err != nil {
+		return nil, a.Errorf("%w", err)
+	}
+	posB, err := threed.ParsePos(b.Text)
+	if err != nil {
+		return nil, b.Errorf("%w", err)
+	}
+	brick := &Brick{
 		ID:      id,
-		A:       threed.ParsePos(parts[0]),
-		B:       threed.ParsePos(parts[1]),
+		A:       posA,
+		B:       posB,
 		Points:  make([]*threed.Pos, 0),
 		RestsOn: make(map[int]bool),
 	}
-	b.CalculatePoints()
-	return b
+	brick.CalculatePoints()
+	return brick, nil
 }
 
 // which brick does this brick support?
-func (b *Brick) Supports(c Chamber) map[int]bool {
-	supports := make(map[int]bool)
+func (b *Brick) Supports(c Chamber) set.Bits {
+	var supports set.Bits
 	for _, p := range b.Points {
 		cand := p.Clone()
 		cand.Z++
 		if above := c.Get(cand); above > 0 && above != b.ID {
-			supports[above] = true
+			supports.Add(above)
 		}
 	}
 	return supports
@@ -130,25 +148,38 @@ func (b *Brick) Maxes(x, y, z int) (int, int, int) {
 	return max(x, b.A.X, b.B.X), max(y, b.A.Y, b.B.Y), max(z, b.A.Z, b.B.Z)
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
-	log := logging.FromContext(ctx)
+// Model builds a 3D model of the bricks, each in its own color.
+func Model(title string, bricks []*Brick) *threed.Model {
+	m := threed.NewModel()
+	m.Title = title
+	for _, b := range bricks {
+		m.AddBox(b.A, b.B, b.ID, twod.Distinct(b.ID))
+	}
+	return m
+}
 
-	scanner := bufio.NewScanner(os.Stdin)
+// settle drops the bricks from the snapshot until they come to rest and
+// indexes which bricks support each other. Brick ids are small, so the
+// indexes are bitsets.
+func settle(ctx context.Context, r io.Reader) ([]*Brick, map[int]set.Bits, map[int]set.Bits, error) {
+	log := logging.FromContext(ctx)
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	bricks := make([]*Brick, 0)
+	bricks := make([]*Brick, 0, len(lines))
 	mX, mY, mZ := 0, 0, 0
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
+	for _, line := range lines {
 		id := len(bricks) + 1
-		brick := NewBrick(id, line)
+		brick, err := NewBrick(id, line)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 		bricks = append(bricks, brick)
 		mX, mY, mZ = brick.Maxes(mX, mY, mZ)
 	}
-	log.Infow("loaded", "maxX", mX, "maxY", mY, "mazZ", mZ, "bricks", len(bricks))
+	log.Debugw("loaded", "maxX", mX, "maxY", mY, "mazZ", mZ, "bricks", len(bricks))
 
 	// Create a 3D array and place all of the bricks into the chamber.
 	chamber := make(Chamber, mZ+2)
@@ -165,6 +196,10 @@ func main() {
 	if logging.IsDebug() {
 		chamber.Print()
 	}
+	exp := threed.ExporterFromContext(ctx)
+	if exp.Enabled() {
+		exp.Add(Model("snapshot", bricks))
+	}
 
 	// sort by initial z to make the falling more efficient.
 	sort.Slice(bricks, func(i, j int) bool {
@@ -187,28 +222,40 @@ func main() {
 	if logging.IsDebug() {
 		chamber.Print()
 	}
+	if exp.Enabled() {
+		exp.Add(Model("settled", bricks))
+	}
 
 	// Calculate supports and supported by; Index and inverse index.
 	// Everyone I support is supported by me.
-	supports := make(map[int]map[int]bool)
-	supportedBy := make(map[int]map[int]bool)
+	supports := make(map[int]set.Bits)
+	supportedBy := make(map[int]set.Bits)
 	for _, b := range bricks {
 		iSupport := b.Supports(chamber)
 		supports[b.ID] = iSupport
 		// invert this index.
-		for s := range iSupport {
-			if _, ok := supportedBy[s]; !ok {
-				supportedBy[s] = make(map[int]bool)
-			}
-			supportedBy[s][b.ID] = true
+		for s := range iSupport.All() {
+			by := supportedBy[s]
+			by.Add(b.ID)
+			supportedBy[s] = by
 		}
 	}
+	return bricks, supports, supportedBy, nil
+}
+
+// Part1 counts the bricks that could be safely disintegrated.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	bricks, supports, supportedBy, err := settle(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
-	// Part 1. count removable bricks
+	// count removable bricks
 	part1 := 0
 	for _, b := range bricks {
 		// easy case, doesn't support anything.
-		if len(supports[b.ID]) == 0 {
+		if supports[b.ID].Len() == 0 {
 			log.Debugw("remove brick that doesn't support anything", "id", b.ID)
 			part1++
 			continue
@@ -216,8 +263,8 @@ func main() {
 
 		// if everything this brick supports is also supported by another brick, then it could be removed
 		canRemove := true
-		for iSupport := range supports[b.ID] {
-			if len(supportedBy[iSupport]) == 1 {
+		for iSupport := range supports[b.ID].All() {
+			if supportedBy[iSupport].Len() == 1 {
 				canRemove = false
 				break
 			}
@@ -227,59 +274,55 @@ func main() {
 			part1++
 		}
 	}
-	log.Infow("answer", "part1", part1)
+	return part1, nil
+}
+
+// Part2 sums the other bricks that would fall for each disintegrated brick.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	bricks, supports, supportedBy, err := settle(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
 	part2 := 0
 	// For every brick, calculate what would fall if just thar brick was removed
 	// not inclusive of the removed brick.
 	for _, b := range bricks {
 		// doesn't support anything, useless.
-		if len(supports[b.ID]) == 0 {
+		if supports[b.ID].Len() == 0 {
 			continue
 		}
 
 		// chain is all bricks that would fall if this was removed.
-		chain := make(map[int]bool)
-		wave := make(map[int]bool)
-		for s := range supports[b.ID] {
+		var chain, wave set.Bits
+		for s := range supports[b.ID].All() {
 			// if I am the only support for a brick above, chain reaction
-			if len(supportedBy[s]) == 1 {
-				chain[s] = true
-				wave[s] = true
+			if supportedBy[s].Len() == 1 {
+				chain.Add(s)
+				wave.Add(s)
 			}
 		}
 
-		for len(wave) > 0 {
-			next := make(map[int]bool)
-			for w := range wave {
-				for s := range supports[w] {
+		for wave.Len() > 0 {
+			var next set.Bits
+			for w := range wave.All() {
+				for s := range supports[w].All() {
 					// s falls if it is only supported by bricks that have also fallen already
-					if allIn(chain, supportedBy[s]) {
-						chain[s] = true
-						next[s] = true
+					if supportedBy[s].SubsetOf(chain) {
+						chain.Add(s)
+						next.Add(s)
 					}
 				}
 			}
 			wave = next
 		}
 
-		if len(chain) > 0 {
-			log.Debugw("removing", "id", b.ID, "fells", len(chain))
-		}
-		part2 += len(chain)
-	}
-	log.Infow("answer", "part2", part2)
-
-	if err := scanner.Err(); err != nil {
-		log.Errorw("read error", "err", err)
-	}
-}
-
-func allIn(set map[int]bool, subset map[int]bool) bool {
-	for s := range subset {
-		if !set[s] {
-			return false
+		fell := chain.Len()
+		if fell > 0 {
+			log.Debugw("removing", "id", b.ID, "fells", fell)
 		}
+		part2 += fell
 	}
-	return true
+	return part2, nil
 }
@@ -1,16 +1,21 @@
-package main
+package day23
 
 import (
 	"bufio"
 	"context"
 	"fmt"
 	"image"
-	"os"
+	"io"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 23, Part1: Part1, Part2: Part2})
+}
+
 type Maze [][]string
 
 func (m Maze) isValid(p image.Point) bool {
@@ -50,6 +55,9 @@ func (m Maze) End() image.Point {
 }
 
 func LogestPath(ctx context.Context, m Maze, slopes bool) int {
+	// Every path found is logged, so this has its own logger to allow
+	// setting the level separately or sampling it.
+	ctx = logging.WithLogger(ctx, logging.FromContext(ctx).Named("dfs"))
 	start := m.Start()
 	end := m.End()
 
@@ -67,7 +75,7 @@ func LogestPath(ctx context.Context, m Maze, slopes bool) int {
 
 	dfs(ctx, m, start, end, visited, &term, answers, 0, slopes)
 
-	logging.FromContext(ctx).Debugw("dfs complete", "answers", answers, slopes)
+	logging.FromContext(ctx).Debugw("dfs complete", "answers", answers, "slopes", slopes)
 	ans := 0
 	for k := range answers {
 		ans = max(ans, k)
@@ -175,11 +183,8 @@ func dfs(ctx context.Context, m Maze, from image.Point, to image.Point, visited
 	}
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
-	log := logging.FromContext(ctx)
-
-	scanner := bufio.NewScanner(os.Stdin)
+func parse(r io.Reader) (Maze, error) {
+	scanner := bufio.NewScanner(r)
 
 	m := make(Maze, 0)
 	for scanner.Scan() {
@@ -190,7 +195,23 @@ func main() {
 		row := strings.Split(line, "")
 		m = append(m, row)
 	}
-	log.Infow("answer", "part1", LogestPath(ctx, m, true))
+	return m, scanner.Err()
+}
+
+// Part1 finds the longest hike when the slopes are icy.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	m, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return LogestPath(ctx, m, true), nil
+}
 
-	log.Infow("answer", "part2", LogestPath(ctx, m, false))
+// Part2 finds the longest hike treating slopes as normal paths.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	m, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return LogestPath(ctx, m, false), nil
 }
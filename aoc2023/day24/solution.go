@@ -1,18 +1,20 @@
-package main
+package day24
 
 import (
-	"bufio"
 	"context"
-	"os"
-	"strconv"
-	"strings"
+	"io"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/adventofcode/pkg/threed"
-	"github.com/mikehelmick/go-functional/slice"
 	"gonum.org/v1/gonum/stat/combin"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 24, Part1: Part1})
+}
+
 type Hail struct {
 	Position *threed.Pos
 	Vector   *threed.Pos
@@ -34,21 +36,15 @@ func (h *Hail) IsFuture(x, y float64) bool {
 	return true
 }
 
-func NewHail(s string) *Hail {
-	s = strings.ReplaceAll(s, " ", "")
-	s = strings.ReplaceAll(s, "@", ",")
-	parts := strings.Split(s, ",")
-	ints := slice.Map(parts, func(s string) int {
-		v, err := strconv.Atoi(s)
-		if err != nil {
-			panic("cannot parse int")
-		}
-		return v
-	})
-	return &Hail{
-		Position: threed.NewPos(ints[0], ints[1], ints[2]),
-		Vector:   threed.NewPos(ints[3], ints[4], ints[5]),
+var hailPattern = parse.MustCompile("{position.x}, {position.y}, {position.z} @ {vector.x}, {vector.y}, {vector.z}")
+
+// NewHail parses a hailstone's position and velocity.
+func NewHail(line parse.Field) (*Hail, error) {
+	h := &Hail{}
+	if err := hailPattern.Match(line, h); err != nil {
+		return nil, err
 	}
+	return h, nil
 }
 
 // part2, just 2d linear intersection
@@ -80,19 +76,21 @@ func linearIntersection(a, b *Hail) (rX *float64, rY *float64) {
 	return
 }
 
-func main() {
-	ctx := logging.WithLogger(context.Background(), logging.DefaultLogger())
+// Part1 counts the future crossings of hailstone paths inside the test area.
+// Part 2 was solved with part2.py.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
 	log := logging.FromContext(ctx)
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
 
-	scanner := bufio.NewScanner(os.Stdin)
-
-	stones := make([]*Hail, 0)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
+	stones := make([]*Hail, 0, len(lines))
+	for _, line := range lines {
+		hail, err := NewHail(line)
+		if err != nil {
+			return nil, err
 		}
-		hail := NewHail(line)
 		stones = append(stones, hail)
 	}
 	log.Debugw("Loaded hail", "hail", stones)
@@ -118,5 +116,5 @@ func main() {
 
 		}
 	}
-	log.Infow("answer", "part1", part1)
+	return part1, nil
 }
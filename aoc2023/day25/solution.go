@@ -1,4 +1,4 @@
-package main
+package day25
 
 /** Note:
 This won't just generally work on any input.
@@ -8,19 +8,26 @@ layout engine. This made it trivial to identify the 3 edges to remove.
 
 I manually removed them from the input file and then this works.
 
-If you uncomment the printf statements in main, you can generate the graph
+If you uncomment the printf statements in Part1, you can generate the graph
 and repeat the procedure.
 */
 
 import (
 	"bufio"
+	"context"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/yourbasic/graph"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 25, Part1: Part1})
+}
+
 type Node struct {
 	ID    string
 	Edges []string
@@ -33,8 +40,10 @@ func NewNode(n string) *Node {
 	}
 }
 
-func main() {
-	scanner := bufio.NewScanner(os.Stdin)
+// Part1 multiplies the sizes of the two groups left once the wires are cut.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
 
 	index := make(map[string]int)
 	next := 0
@@ -72,7 +81,10 @@ func main() {
 		}
 	}
 	//fmt.Printf("}\n")
-	fmt.Printf("total notes: %+v\n", len(nodes))
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	log.Debugw("loaded", "nodes", len(nodes))
 
 	g := graph.New(len(nodes))
 	for _, n := range nodes {
@@ -83,8 +95,7 @@ func main() {
 
 	com := graph.Components(g)
 	if len(com) != 2 {
-		panic("done messed up")
+		return nil, fmt.Errorf("done messed up, found %d components", len(com))
 	}
-
-	fmt.Printf("answer: %v\n", len(com[0])*len(com[1]))
+	return len(com[0]) * len(com[1]), nil
 }
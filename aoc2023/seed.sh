@@ -8,4 +8,12 @@ fi
 
 mkdir $path
 cp starter/* $path
+sed -i.bak -e "s/^package starter/package day${1}/" -e "s/Day: 0,/Day: $(expr ${1} + 0),/" $path/solution.go
+rm $path/solution.go.bak
 touch $path/input.txt
+
+# register the new day with the launcher
+awk -v imp="github.com/mikehelmick/adventofcode/aoc2023/${path}" \
+    '$0 == ")" && !done { printf "\t_ \"%s\"\n", imp; done = 1 } { print }' \
+    all/all.go > all/all.go.tmp && mv all/all.go.tmp all/all.go
+gofmt -w all/all.go
@@ -0,0 +1,42 @@
+package starter
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2023, Day: 0, Part1: Part1})
+}
+
+type Data struct {
+}
+
+func parse(ctx context.Context, r io.Reader) ([]string, error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
+
+	lines := make([]string, 0)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		log.Debugw("parsed line", "line", line)
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// Part1 solves part 1.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	lines, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return len(lines), nil
+}
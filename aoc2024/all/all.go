@@ -0,0 +1,21 @@
+// Package all registers every 2024 solution with the solver registry.
+package all
+
+import (
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day01"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day02"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day03"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day04"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day05"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day06"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day07"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day08"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day09"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day10"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day11"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day12"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day13"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day14"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day15"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/day19"
+)
@@ -0,0 +1,106 @@
+package day01
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/counter"
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 1, Part1: Part1, Part2: Part2})
+}
+
+func parse(ctx context.Context, r io.Reader) (sort.IntSlice, sort.IntSlice, error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
+
+	left := make(sort.IntSlice, 0, 1000)
+	right := make(sort.IntSlice, 0, 1000)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		inParts := strings.Split(line, " ")
+		parts := make([]string, 0, 2)
+		for _, p := range inParts {
+			if p != "" {
+				parts = append(parts, p)
+			}
+		}
+
+		if len(parts) != 2 {
+			log.Errorw("invalid input", "line", line, "parts", parts)
+			return nil, nil, fmt.Errorf("invalid input: %q", line)
+		}
+
+		lInt, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		left = append(left, lInt)
+		rInt, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, nil, err
+		}
+		right = append(right, rInt)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	left.Sort()
+	right.Sort()
+	if len(left) != len(right) {
+		log.Errorw("invalid input", "left", len(left), "right", len(right))
+		return nil, nil, fmt.Errorf("invalid input: %d left and %d right", len(left), len(right))
+	}
+	return left, right, nil
+}
+
+// Part1 sums the distances between the sorted lists.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	left, right, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	part1 := 0
+	for i, l := range left {
+		r := right[i]
+		if diff := l - r; diff > 0 {
+			part1 += diff
+		} else {
+			part1 += (-1 * diff)
+		}
+	}
+	return part1, nil
+}
+
+// Part2 is the similarity score, how often each left value is in the right list.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	left, right, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	rightIndex := indexList(right)
+	part2 := 0
+	for _, lValue := range left {
+		part2 += lValue * rightIndex.Count(lValue)
+	}
+	return part2, nil
+}
+
+func indexList(list []int) counter.Counter[int] {
+	return counter.New(list...)
+}
@@ -1,14 +1,19 @@
-package main
+package day02
 
 import (
 	"bufio"
-	"os"
+	"context"
+	"io"
 	"strconv"
 	"strings"
 
-	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 2, Part1: Part1, Part2: Part2})
+}
+
 type Report struct {
 	Values []int
 }
@@ -80,26 +85,45 @@ func (r Report) damperSafe() bool {
 	return false
 }
 
-func main() {
-	log := logging.DefaultLogger()
-	scanner := bufio.NewScanner(os.Stdin)
+func parse(r io.Reader) ([]*Report, error) {
+	scanner := bufio.NewScanner(r)
 
 	reports := make([]*Report, 0)
 	for scanner.Scan() {
 		line := scanner.Text()
 		reports = append(reports, NewReport(line))
 	}
+	return reports, scanner.Err()
+}
+
+// Part1 counts the safe reports.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	reports, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
 
 	part1 := 0
-	part2 := 0
 	for _, report := range reports {
 		if report.safe() {
 			part1++
 		}
+	}
+	return part1, nil
+}
+
+// Part2 counts the reports that are safe with the problem dampener.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	reports, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	part2 := 0
+	for _, report := range reports {
 		if report.damperSafe() {
 			part2++
 		}
 	}
-	log.Infow("answer", "part1", part1)
-	log.Infow("answer", "part2", part2)
+	return part2, nil
 }
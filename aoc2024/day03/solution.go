@@ -0,0 +1,97 @@
+package day03
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 3, Part1: Part1, Part2: Part2})
+}
+
+func solveMul(s string) int64 {
+	if !strings.HasPrefix(s, "mul(") || !strings.HasSuffix(s, ")") {
+		panic("invalid input")
+	}
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "mul("), ")")
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		panic("invalid input")
+	}
+	a, err := strconv.Atoi(parts[0])
+	if err != nil {
+		panic(err)
+	}
+	b, err := strconv.Atoi(parts[1])
+	if err != nil {
+		panic(err)
+	}
+	return int64(a * b)
+}
+
+// scan solves every mul instruction in the memory. All of them are summed
+// into part1 but part2 only includes those that are enabled.
+func scan(ctx context.Context, r io.Reader) (part1 int64, part2 int64, err error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
+
+	re, err := regexp.Compile(`(do\(\))|(don't\(\))|(mul\(\d{1,3},\d{1,3}\))`)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	enabled := true
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		matches := re.FindAllString(line, -1)
+		if matches == nil {
+			log.Errorw("no matches found", "line", line)
+			continue
+		}
+
+		for _, match := range matches {
+			if match == `do()` {
+				log.Debugw("enabling mul", "match", match)
+				enabled = true
+				continue
+			} else if match == `don't()` {
+				log.Debugw("disabling mul", "match", match)
+				enabled = false
+				continue
+			}
+			log.Debugw("solving match", "match", match)
+			ans := solveMul(match)
+			part1 += ans
+			if enabled {
+				part2 += ans
+			}
+		}
+	}
+	return part1, part2, scanner.Err()
+}
+
+// Part1 adds up the results of all of the multiplications.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	part1, _, err := scan(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return part1, nil
+}
+
+// Part2 only adds up the multiplications that are enabled.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	_, part2, err := scan(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return part2, nil
+}
@@ -1,13 +1,19 @@
-package main
+package day04
 
 import (
 	"bufio"
+	"context"
 	"fmt"
-	"os"
+	"io"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 4, Part1: Part1, Part2: Part2})
+}
+
 type pos struct {
 	x, y int
 }
@@ -52,22 +58,22 @@ func (x MASDict) IsPrefix(s string) bool {
 	return s == "M" || s == "MA"
 }
 
-func (g Grid) CountOuccrences() int {
-	log := logging.DefaultLogger()
+func (g Grid) CountOuccrences(ctx context.Context) int {
+	log := logging.FromContext(ctx)
 	count := 0
 	for r, row := range g {
 		for c := range row {
 			for _, o := range offsets {
 				log.Debugw("origin", "pos", pos{r, c}, "offset", o)
-				count += g.search("", pos{r, c}, o, XMASDict{})
+				count += g.search(ctx, "", pos{r, c}, o, XMASDict{})
 			}
 		}
 	}
 	return count
 }
 
-func (g Grid) search(prefix string, p pos, dir pos, dict Dict) int {
-	log := logging.DefaultLogger()
+func (g Grid) search(ctx context.Context, prefix string, p pos, dir pos, dict Dict) int {
+	log := logging.FromContext(ctx)
 
 	candidate := prefix + g[p.x][p.y]
 	if dict.IsWord(candidate) {
@@ -85,14 +91,14 @@ func (g Grid) search(prefix string, p pos, dir pos, dict Dict) int {
 	if next.x < 0 || next.x >= len(g) || next.y < 0 || next.y >= len(g[0]) {
 		return 0
 	}
-	return g.search(candidate, next, dir, dict)
+	return g.search(ctx, candidate, next, dir, dict)
 }
 
-func (g Grid) findMas() int {
+func (g Grid) findMas(ctx context.Context) int {
 	// only the diagonals are valid
 	offsets := []pos{{-1, -1}, {-1, 1}, {1, 1}, {1, -1}}
 
-	log := logging.DefaultLogger()
+	log := logging.FromContext(ctx)
 
 	centers := make(map[pos]bool)
 	count := 0
@@ -101,7 +107,7 @@ func (g Grid) findMas() int {
 			for _, o := range offsets {
 				p := pos{r, c}
 				log.Debugw("origin", "pos", p, "offset", o)
-				if (g.search("", p, o, MASDict{})) > 0 {
+				if (g.search(ctx, "", p, o, MASDict{})) > 0 {
 					center := p.add(o)
 					if _, ok := centers[center]; !ok {
 						centers[center] = true
@@ -115,9 +121,9 @@ func (g Grid) findMas() int {
 	return count
 }
 
-func main() {
-	log := logging.DefaultLogger()
-	scanner := bufio.NewScanner(os.Stdin)
+func parse(ctx context.Context, r io.Reader) (Grid, error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
 
 	grid := make(Grid, 0)
 	for scanner.Scan() {
@@ -128,8 +134,24 @@ func main() {
 		}
 		grid = append(grid, row)
 	}
+	log.Debugw("loaded grid", "grid", grid)
+	return grid, scanner.Err()
+}
 
-	log.Infow("loaded grid", "grid", grid)
-	log.Infow("count", "count", grid.CountOuccrences())
-	log.Infow("mascount", "count", grid.findMas())
+// Part1 counts every XMAS in the word search.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	grid, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return grid.CountOuccrences(ctx), nil
+}
+
+// Part2 counts the MAS crosses.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	grid, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return grid.findMas(ctx), nil
 }
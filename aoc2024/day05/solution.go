@@ -1,15 +1,22 @@
-package main
+package day05
 
 import (
 	"bufio"
-	"os"
+	"context"
+	"fmt"
+	"io"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 5, Part1: Part1, Part2: Part2})
+}
+
 type Rule struct {
 	Before int
 	After  int
@@ -51,9 +58,11 @@ func NewRule(s string) *Rule {
 	return &Rule{Before: before, After: after}
 }
 
-func main() {
-	log := logging.DefaultLogger()
-	scanner := bufio.NewScanner(os.Stdin)
+// check sums the middle page of the updates that are already in order into
+// part1, and of the others once they have been sorted into part2.
+func check(ctx context.Context, r io.Reader) (part1 int, part2 int, err error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
 
 	rules := make([]*Rule, 0, 100)
 	ruleMap := make(map[int][]*Rule)
@@ -67,28 +76,43 @@ func main() {
 		rule.AddToMap(ruleMap)
 	}
 
-	part1 := 0
-	part2 := 0
 	for scanner.Scan() {
 		line := scanner.Text()
 		pages := getPages(line)
 
 		valid := pageOrderValid(pages, rules)
 		if valid {
-			log.Infow("valid row", "pages", pages)
+			log.Debugw("valid row", "pages", pages)
 			part1 += pages[len(pages)/2]
 		} else {
-			log.Infow("invalid row, sorting", "pages", pages)
+			log.Debugw("invalid row, sorting", "pages", pages)
 			sortPages(pages, ruleMap)
-			if pageOrderValid(pages, rules) {
-				part2 += pages[len(pages)/2]
-			} else {
-				log.Fatalw("invalid row after sorting", "pages", pages)
+			if !pageOrderValid(pages, rules) {
+				return 0, 0, fmt.Errorf("invalid row after sorting: %v", pages)
 			}
+			part2 += pages[len(pages)/2]
 		}
 	}
-	log.Infow("Part 1", "valid", part1)
-	log.Infow("Part 2", "valid", part2)
+	return part1, part2, scanner.Err()
+}
+
+// Part1 sums the middle pages of the correctly ordered updates.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	part1, _, err := check(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return part1, nil
+}
+
+// Part2 sums the middle pages of the incorrectly ordered updates after
+// putting them in order.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	_, part2, err := check(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return part2, nil
 }
 
 func pageOrderValid(pages []int, rules []*Rule) bool {
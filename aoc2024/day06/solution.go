@@ -0,0 +1,218 @@
+package day06
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/anim"
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parallel"
+	"github.com/mikehelmick/adventofcode/pkg/set"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/adventofcode/pkg/twod"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 6, Part1: Part1, Part2: Part2})
+}
+
+const EMPTY = 0
+const WALL = 1
+
+type Maze [][]int
+
+// palette colors the open floor, walls, visited floor and the guard in
+// recorded frames.
+var palette = color.Palette{
+	color.Black,
+	color.RGBA{0x60, 0x60, 0x60, 0xff},
+	color.RGBA{0x33, 0x66, 0xcc, 0xff},
+	color.RGBA{0xff, 0x33, 0x33, 0xff},
+}
+
+func (m Maze) String(visited set.Set[twod.Pos]) string {
+	b := strings.Builder{}
+	for r, row := range m {
+		for c, cell := range row {
+			if cell == EMPTY {
+				if visited.Contains(twod.Pos{Row: r, Col: c}) {
+					b.WriteRune('X')
+				} else {
+					b.WriteRune('.')
+				}
+			} else {
+				b.WriteRune('#')
+			}
+		}
+		b.WriteRune('\n')
+	}
+	return b.String()
+}
+
+// Frame draws the maze and the guard as a recorded frame.
+func (m Maze) Frame(visited set.Set[twod.Pos], guard *Guard) anim.Frame {
+	f := anim.FromText(m.String(visited), ".#X", palette)
+	f.Set(guard.Position.Row, guard.Position.Col, 3)
+	return f
+}
+
+func (m Maze) Clone() Maze {
+	newMaze := make(Maze, len(m))
+	for i, row := range m {
+		newMaze[i] = make([]int, len(row))
+		copy(newMaze[i], row)
+	}
+	return newMaze
+}
+
+type Guard struct {
+	Position    *twod.Pos
+	Dir         string
+	Orientation *twod.Pos
+}
+
+func (g *Guard) Clone() *Guard {
+	return &Guard{
+		Position:    g.Position.Clone(),
+		Dir:         g.Dir,
+		Orientation: g.Orientation.Clone(),
+	}
+}
+
+func (g *Guard) TurnRight() {
+	if g.Dir == "^" {
+		g.Dir = ">"
+		g.Orientation = twod.DirArrows[">"]
+	} else if g.Dir == ">" {
+		g.Dir = "V"
+		g.Orientation = twod.DirArrows["V"]
+	} else if g.Dir == "V" {
+		g.Dir = "<"
+		g.Orientation = twod.DirArrows["<"]
+	} else if g.Dir == "<" {
+		g.Dir = "^"
+		g.Orientation = twod.DirArrows["^"]
+	}
+}
+
+func (g *Guard) String() string {
+	return fmt.Sprintf("Guard{Position: %v, Dir: %s, Orientation: %v}", g.Position, g.Dir, g.Orientation)
+}
+
+func parse(r io.Reader) (Maze, *Guard, error) {
+	scanner := bufio.NewScanner(r)
+
+	maze := make(Maze, 0)
+	var guard *Guard
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		row := make([]int, len(line))
+		for i, c := range line {
+			if c == '#' {
+				row[i] = WALL
+				continue
+			}
+			if c != '.' {
+				// found the guard
+				guard = &Guard{
+					Position:    &twod.Pos{Row: len(maze), Col: i},
+					Dir:         string(c),
+					Orientation: twod.DirArrows[string(c)],
+				}
+			}
+		}
+		maze = append(maze, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if guard == nil {
+		return nil, nil, fmt.Errorf("no guard in the map")
+	}
+	return maze, guard, nil
+}
+
+// Part1 counts the positions the guard visits before leaving the area.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	maze, guard, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	log.Debugw("Guard", "guard", guard)
+
+	visited, _ := traverse(maze, guard, anim.FromContext(ctx))
+	if logging.IsDebug() {
+		log.Debugf("maze :\n%s", maze.String(visited))
+	}
+	return visited.Len(), nil
+}
+
+// Part2 counts the positions where a new obstruction would put the guard in
+// a loop. Only positions on the original path can change the route, and
+// each one is simulated on its own worker.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	starting, startingGuard, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	visited, _ := traverse(starting.Clone(), startingGuard.Clone(), nil)
+
+	return parallel.CountSeq(ctx, parallel.Options{}, visited.All(), func(_ context.Context, newBlock twod.Pos) (bool, error) {
+		maze := starting.Clone()
+		maze[newBlock.Row][newBlock.Col] = WALL
+		guard := startingGuard.Clone()
+
+		_, exited := traverse(maze, guard, nil)
+		return !exited, nil
+	})
+}
+
+// step is a position of the guard and the way it is facing, seeing one
+// twice means the guard is in a loop.
+type step struct {
+	Pos twod.Pos
+	Dir string
+}
+
+// traverse walks the guard until it leaves the maze or loops, adding a frame
+// to rec for each step. It returns the positions visited.
+func traverse(maze Maze, guard *Guard, rec *anim.Recorder) (set.Set[twod.Pos], bool) {
+	exited := false
+	visited := set.New[twod.Pos]()
+	steps := set.New[step]()
+	for {
+		if rec.Enabled() {
+			rec.Add(maze.Frame(visited, guard))
+		}
+		s := step{Pos: *guard.Position, Dir: guard.Dir}
+		if steps.Contains(s) {
+			break
+		}
+		steps.Add(s)
+		visited.Add(s.Pos)
+
+		next := guard.Position.Clone()
+		next.Add(guard.Orientation)
+		if next.Row < 0 || next.Row >= len(maze) || next.Col < 0 || next.Col >= len(maze[0]) {
+			exited = true
+			break
+		}
+
+		if maze[next.Row][next.Col] == WALL {
+			guard.TurnRight()
+			continue
+		} else {
+			// Move forward
+			guard.Position = next
+		}
+	}
+	return visited, exited
+}
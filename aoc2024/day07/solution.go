@@ -1,15 +1,20 @@
-package main
+package day07
 
 import (
-	"bufio"
+	"context"
 	"fmt"
-	"os"
-	"strings"
+	"io"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/adventofcode/pkg/straid"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 7, Part1: Part1, Part2: Part2})
+}
+
 type operator int
 
 const (
@@ -76,19 +81,30 @@ type Equation struct {
 	Solution OperatorVec
 }
 
-func NewEquation(line string) Equation {
+// NewEquation parses an equation written as "answer: value value ...".
+func NewEquation(line parse.Field) (Equation, error) {
 	eq := Equation{
 		Values:   make([]int64, 0),
 		Solution: make(OperatorVec, 0),
 	}
-	answerAndValues := strings.Split(line, ":")
-	eq.Answer = straid.AsInt(answerAndValues[0])
+	answer, values, err := line.Cut(":")
+	if err != nil {
+		return eq, err
+	}
+	a, err := answer.Int()
+	if err != nil {
+		return eq, err
+	}
+	eq.Answer = int64(a)
 
-	values := strings.Split(strings.TrimSpace(answerAndValues[1]), " ")
-	for _, v := range values {
-		eq.Values = append(eq.Values, straid.AsInt(v))
+	ints, err := values.SplitInts()
+	if err != nil {
+		return eq, err
 	}
-	return eq
+	for _, v := range ints {
+		eq.Values = append(eq.Values, int64(v))
+	}
+	return eq, nil
 }
 
 func (e *Equation) String() string {
@@ -125,36 +141,47 @@ func (e *Equation) Solvable(allowConcat bool) bool {
 	}
 }
 
-func main() {
-	log := logging.DefaultLogger()
-	scanner := bufio.NewScanner(os.Stdin)
+func parseEquations(r io.Reader) ([]Equation, error) {
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
 
-	equations := make([]Equation, 0)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			break
+	equations := make([]Equation, 0, len(lines))
+	for _, line := range lines {
+		eq, err := NewEquation(line)
+		if err != nil {
+			return nil, err
 		}
-		equations = append(equations, NewEquation(line))
+		equations = append(equations, eq)
 	}
+	return equations, nil
+}
 
-	var part1 int64
-	for _, eq := range equations {
-		log.Debugw("Equation", "eq", eq)
-		if eq.Solvable(false) {
-			log.Infow("Solution", "eq", eq)
-			part1 += eq.Answer
-		}
+func calibrate(ctx context.Context, r io.Reader, allowConcat bool) (any, error) {
+	log := logging.FromContext(ctx)
+	equations, err := parseEquations(r)
+	if err != nil {
+		return nil, err
 	}
-	log.Infow("answer", "part1", part1)
 
-	var part2 int64
+	var total int64
 	for _, eq := range equations {
 		log.Debugw("Equation", "eq", eq)
-		if eq.Solvable(true) {
-			log.Infow("Solution", "eq", eq)
-			part2 += eq.Answer
+		if eq.Solvable(allowConcat) {
+			log.Debugw("Solution", "eq", eq)
+			total += eq.Answer
 		}
 	}
-	log.Infow("answer", "part2", part2)
+	return total, nil
+}
+
+// Part1 sums the equations that can be made true with + and *.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	return calibrate(ctx, r, false)
+}
+
+// Part2 also allows the concatenation operator.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	return calibrate(ctx, r, true)
 }
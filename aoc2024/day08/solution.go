@@ -0,0 +1,136 @@
+package day08
+
+import (
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/mikehelmick/adventofcode/pkg/combinatorics"
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/adventofcode/pkg/twod"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 8, Part1: Part1, Part2: Part2})
+}
+
+type Grid [][]string
+
+func (g Grid) String() string {
+	str := ""
+	for _, row := range g {
+		for _, c := range row {
+			str += c
+		}
+		str += "\n"
+	}
+	return str
+}
+
+func (g Grid) Copy() Grid {
+	c := make(Grid, len(g))
+	for i, row := range g {
+		c[i] = make([]string, len(row))
+		copy(c[i], row)
+	}
+	return c
+}
+
+func parse(ctx context.Context, r io.Reader) (Grid, map[string][]*twod.Pos, error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
+
+	antennae := make(map[string][]*twod.Pos)
+	grid := make(Grid, 0)
+	for scanner.Scan() {
+		line := scanner.Text()
+		row := make([]string, 0, len(line))
+		for i, c := range line {
+			row = append(row, string(c))
+
+			if c != '.' {
+				pos := twod.NewPos(len(grid), i)
+				if _, ok := antennae[string(c)]; !ok {
+					antennae[string(c)] = make([]*twod.Pos, 0, 2)
+				}
+				antennae[string(c)] = append(antennae[string(c)], pos)
+			}
+		}
+		grid = append(grid, row)
+	}
+	log.Debugw("antennae", "antennae", antennae)
+	return grid, antennae, scanner.Err()
+}
+
+// Part1 counts the antinodes that are twice as far from one antenna as the
+// other.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	grid, antennae, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	antinodes := make(map[twod.Pos]bool)
+	for _, locs := range antennae {
+		pairs := combinatorics.AllPairs(locs)
+		for _, pair := range pairs {
+			slopeRow := pair[0].Row - pair[1].Row
+			slopeCol := pair[0].Col - pair[1].Col
+
+			antinode := twod.NewPos(pair[0].Row+slopeRow, pair[0].Col+slopeCol)
+			if isValid(antinode, grid) {
+				grid[antinode.Row][antinode.Col] = "#"
+				antinodes[*antinode] = true
+			}
+			antinode = twod.NewPos(pair[1].Row-slopeRow, pair[1].Col-slopeCol)
+			if isValid(antinode, grid) {
+				grid[antinode.Row][antinode.Col] = "#"
+				antinodes[*antinode] = true
+			}
+		}
+	}
+	log.Debugf("after:\n%s", grid)
+	return len(antinodes), nil
+}
+
+// Part2 counts the antinodes at any grid position in line with two antennae.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	grid, antennae, err := parse(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	antinodes := make(map[twod.Pos]bool)
+	for _, locs := range antennae {
+		pairs := combinatorics.AllPairs(locs)
+		for _, pair := range pairs {
+			slopeRow := pair[0].Row - pair[1].Row
+			slopeCol := pair[0].Col - pair[1].Col
+
+			antinodes[*pair[0]] = true
+			antinodes[*pair[1]] = true
+
+			antinode := twod.NewPos(pair[0].Row+slopeRow, pair[0].Col+slopeCol)
+			for isValid(antinode, grid) {
+				grid[antinode.Row][antinode.Col] = "#"
+				antinodes[*antinode] = true
+				antinode = twod.NewPos(antinode.Row+slopeRow, antinode.Col+slopeCol)
+			}
+			antinode = twod.NewPos(pair[1].Row-slopeRow, pair[1].Col-slopeCol)
+			for isValid(antinode, grid) {
+				grid[antinode.Row][antinode.Col] = "#"
+				antinodes[*antinode] = true
+				antinode = twod.NewPos(antinode.Row-slopeRow, antinode.Col-slopeCol)
+			}
+		}
+	}
+	log.Debugf("after:\n%s", grid)
+	return len(antinodes), nil
+}
+
+func isValid(p *twod.Pos, grid Grid) bool {
+	return p.Row >= 0 && p.Row < len(grid) && p.Col >= 0 && p.Col < len(grid[0])
+}
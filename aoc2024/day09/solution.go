@@ -0,0 +1,162 @@
+package day09
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/adventofcode/pkg/straid"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 9, Part1: Part1, Part2: Part2})
+}
+
+// expand expands the disk map into one entry per block, returning the
+// blocks and the number of files.
+func expand(ctx context.Context, r io.Reader) ([]string, int, error) {
+	log := logging.FromContext(ctx)
+	// The disk map is one very long line.
+	input, err := parse.Bytes(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	line := bytes.TrimSpace(input)
+
+	log.Debugw("line", "line", string(line))
+
+	diskBuilder := make([]string, 0)
+	fileID := 0
+	space := false
+	for col, c := range line {
+		i, err := parse.Digit(rune(c))
+		if err != nil {
+			return nil, 0, &parse.Error{Line: 1, Col: col + 1, Err: err}
+		}
+		if space {
+			for j := 0; j < i; j++ {
+				diskBuilder = append(diskBuilder, ".")
+			}
+			space = false
+		} else {
+			for j := 0; j < i; j++ {
+				diskBuilder = append(diskBuilder, fmt.Sprintf("%d", fileID))
+			}
+			space = true
+			fileID++
+		}
+	}
+	log.Debugw("disk", "disk", strings.Join(diskBuilder, ""))
+	return diskBuilder, fileID, nil
+}
+
+// Part1 compacts the disk by moving single blocks and returns the checksum.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	diskBuilder, _, err := expand(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	front := 0
+	back := len(diskBuilder) - 1
+	for front < back {
+		for diskBuilder[front] != "." {
+			front++
+		}
+		for diskBuilder[back] == "." {
+			back--
+		}
+		if front >= back {
+			break
+		}
+
+		log.Debugw("moving", "front", front, "val", diskBuilder[front], "back", back, "backVal", diskBuilder[back])
+
+		diskBuilder[front] = diskBuilder[back]
+		front++
+		diskBuilder[back] = "."
+		back--
+	}
+	var checksum int64
+	for i, val := range diskBuilder {
+		if val != "." {
+			checksum += straid.AsInt(val) * int64(i)
+		}
+	}
+
+	log.Debugw("defraged", "disk", strings.Join(diskBuilder, ""))
+	return checksum, nil
+}
+
+// Part2 compacts the disk by moving whole files and returns the checksum.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	disk2, fileID, err := expand(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	for toMove := fileID - 1; toMove > 0; toMove-- {
+		fileIDStr := fmt.Sprintf("%d", toMove)
+		index := 0
+		length := 0
+		for i, val := range disk2 {
+			if index == 0 && val == fileIDStr {
+				index = i
+				length = 1
+				continue
+			} else if index > 0 && val == fileIDStr {
+				length++
+			} else if index > 0 && val != fileIDStr {
+				break
+			}
+		}
+		log.Debugw("defragging", "toMove", toMove, "index", index, "length", length)
+
+		// find an empty space to put this file in
+		moveTo := 0
+		moveBlock := false
+		for ; moveTo < len(disk2)-length; moveTo++ {
+			if disk2[moveTo] == "." {
+				found := true
+				for i := 0; i < length; i++ {
+					if disk2[moveTo+i] == "." {
+						continue
+					} else {
+						found = false
+						break
+					}
+				}
+				if found {
+					moveBlock = true
+					break
+				}
+			}
+		}
+		if moveBlock {
+			if moveTo < index {
+				log.Debugw("moving", "toMove", toMove, "from", index, "to", moveTo)
+				for i := 0; i < length; i++ {
+					disk2[moveTo+i] = fileIDStr
+					disk2[index+i] = "."
+				}
+			}
+		}
+
+	}
+	var checksum int64
+	for i, val := range disk2 {
+		if val != "." {
+			checksum += straid.AsInt(val) * int64(i)
+		}
+	}
+
+	log.Debugw("defraged", "disk", strings.Join(disk2, ""))
+	return checksum, nil
+}
@@ -0,0 +1,209 @@
+package day10
+
+import (
+	"context"
+	"io"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/adventofcode/pkg/twod"
+)
+
+func init() {
+	solver.Register(&solver.Day{
+		Year:  2024,
+		Day:   10,
+		Part1: Part1,
+		Part2: Part2,
+		Alternates: map[int][]solver.Impl{
+			1: {{Name: "dfs", Func: Part1DFS}},
+			2: {{Name: "bfs", Func: Part2BFS}},
+		},
+	})
+}
+
+type Grid [][]int64
+
+// inBounds reports whether p is on the grid.
+func (g Grid) inBounds(p *twod.Pos) bool {
+	return p.Row >= 0 && p.Row < len(g) && p.Col >= 0 && p.Col < len(g[0])
+}
+
+func doDFS(grid Grid, start *twod.Pos) int {
+	if grid[start.Row][start.Col] == 9 {
+		return 1
+	}
+
+	val := grid[start.Row][start.Col]
+	paths := 0
+	candidates := start.Neighbors(grid.inBounds)
+
+	for _, cand := range candidates {
+		if nextV := grid[cand.Row][cand.Col]; nextV == val+1 {
+			paths += doDFS(grid, cand)
+		}
+	}
+
+	return paths
+}
+
+// findPeaks is doDFS, but remembers which peaks were reached instead of
+// counting the trails to them.
+func findPeaks(grid Grid, start *twod.Pos, nines map[twod.Pos]bool) {
+	if grid[start.Row][start.Col] == 9 {
+		nines[*start] = true
+		return
+	}
+
+	val := grid[start.Row][start.Col]
+	candidates := start.Neighbors(grid.inBounds)
+
+	for _, cand := range candidates {
+		if nextV := grid[cand.Row][cand.Col]; nextV == val+1 {
+			findPeaks(grid, cand, nines)
+		}
+	}
+}
+
+func doBFS(grid Grid, start twod.Pos) int {
+	waveFront := []twod.Pos{start}
+
+	nines := make(map[twod.Pos]bool)
+
+	for len(waveFront) > 0 {
+		next := make(map[twod.Pos]bool)
+		for _, pos := range waveFront {
+			if grid[pos.Row][pos.Col] == 9 {
+				nines[pos] = true
+				continue
+			}
+
+			val := grid[pos.Row][pos.Col]
+			candidates := pos.Neighbors(grid.inBounds)
+			for _, cand := range candidates {
+				if nextV := grid[cand.Row][cand.Col]; nextV == val+1 {
+					next[*cand] = true
+				}
+			}
+		}
+		waveFront = make([]twod.Pos, 0, len(next))
+		for pos := range next {
+			waveFront = append(waveFront, pos)
+		}
+	}
+
+	return len(nines)
+}
+
+// countBFS is doBFS, but carries the number of trails that reach each
+// position in the wave front.
+func countBFS(grid Grid, start twod.Pos) int {
+	waveFront := map[twod.Pos]int{start: 1}
+
+	paths := 0
+	for len(waveFront) > 0 {
+		next := make(map[twod.Pos]int)
+		for pos, count := range waveFront {
+			if grid[pos.Row][pos.Col] == 9 {
+				paths += count
+				continue
+			}
+
+			val := grid[pos.Row][pos.Col]
+			candidates := pos.Neighbors(grid.inBounds)
+			for _, cand := range candidates {
+				if nextV := grid[cand.Row][cand.Col]; nextV == val+1 {
+					next[*cand] += count
+				}
+			}
+		}
+		waveFront = next
+	}
+
+	return paths
+}
+
+func loadGrid(ctx context.Context, r io.Reader) (Grid, []*twod.Pos, error) {
+	log := logging.FromContext(ctx)
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	grid, err := parse.Grid(lines, func(c rune) (int64, error) {
+		d, err := parse.Digit(c)
+		return int64(d), err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	starts := make([]*twod.Pos, 0)
+	for r, row := range grid {
+		for c, height := range row {
+			if height == 0 {
+				starts = append(starts, twod.NewPos(r, c))
+			}
+		}
+	}
+	log.Debugw("loaded", "starts", starts, "grid", grid)
+	return grid, starts, nil
+}
+
+// Part1 sums the number of peaks reachable from each trailhead.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	grid, starts, err := loadGrid(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	part1 := 0
+	for _, start := range starts {
+		part1 += doBFS(grid, *start)
+	}
+	return part1, nil
+}
+
+// Part2 sums the number of distinct trails from each trailhead.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	grid, starts, err := loadGrid(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	part2 := 0
+	for _, start := range starts {
+		part2 += doDFS(grid, start)
+	}
+	return part2, nil
+}
+
+// Part1DFS finds the peaks with a depth first search.
+func Part1DFS(ctx context.Context, r io.Reader) (any, error) {
+	grid, starts, err := loadGrid(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	part1 := 0
+	for _, start := range starts {
+		nines := make(map[twod.Pos]bool)
+		findPeaks(grid, start, nines)
+		part1 += len(nines)
+	}
+	return part1, nil
+}
+
+// Part2BFS counts the trails with a breadth first search.
+func Part2BFS(ctx context.Context, r io.Reader) (any, error) {
+	grid, starts, err := loadGrid(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	part2 := 0
+	for _, start := range starts {
+		part2 += countBFS(grid, *start)
+	}
+	return part2, nil
+}
@@ -0,0 +1,68 @@
+package day11
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/mikehelmick/adventofcode/pkg/counter"
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/adventofcode/pkg/straid"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 11, Part1: Part1, Part2: Part2})
+}
+
+// change is what a stone turns into after one blink.
+func change(stone int64) []int64 {
+	if stone == 0 {
+		return []int64{1}
+	}
+	asStr := fmt.Sprintf("%d", stone)
+	if len(asStr)%2 == 0 {
+		return []int64{straid.AsInt(asStr[0 : len(asStr)/2]), straid.AsInt(asStr[len(asStr)/2:])}
+	}
+	return []int64{stone * 2024}
+}
+
+// blink counts the stones after blinking the given number of times. Stones
+// with the same number always change the same way, so only the number of
+// each is tracked.
+func blink(ctx context.Context, r io.Reader, rounds int) (any, error) {
+	log := logging.FromContext(ctx)
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no stones in the input")
+	}
+	parts, err := lines[0].SplitInts()
+	if err != nil {
+		return nil, err
+	}
+	stones := counter.New[int64]()
+	for _, part := range parts {
+		stones.Add(int64(part))
+	}
+	log.Debugw("stones", "stones", stones)
+
+	for i := 0; i < rounds; i++ {
+		stones = counter.Transform(stones, change)
+		log.Debugw("blink", "round", i+1, "stones", len(stones), "total", stones.Total())
+	}
+	return int64(stones.Total()), nil
+}
+
+// Part1 counts the stones after 25 blinks.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	return blink(ctx, r, 25)
+}
+
+// Part2 counts the stones after 75 blinks.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	return blink(ctx, r, 75)
+}
@@ -1,20 +1,29 @@
-package main
+package day12
 
 import (
 	"bufio"
+	"context"
 	"fmt"
-	"os"
+	"image/color"
+	"io"
 	"sort"
 
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/adventofcode/pkg/twod"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 12, Part1: Part1, Part2: Part2})
+}
+
 const PROCESSED = "#"
 
 type Grid [][]string
 
-func (g Grid) CalculateFence(r int, c int) (int, int) {
+// CalculateFence finds the region containing r, c and returns its fence
+// cost, its bulk cost and the plots in it.
+func (g Grid) CalculateFence(ctx context.Context, r int, c int) (int, int, map[twod.Pos]bool) {
 	validFn := func(p *twod.Pos) bool {
 		return p.Row >= 0 && p.Row < len(g) && p.Col >= 0 && p.Col < len(g[p.Row])
 	}
@@ -96,10 +105,10 @@ func (g Grid) CalculateFence(r int, c int) (int, int) {
 	for p := range allPoints {
 		g[p.Row][p.Col] = PROCESSED
 	}
-	log := logging.DefaultLogger()
+	log := logging.FromContext(ctx)
 	log.Debugw("DEBUG", "numPerimiter", perimiterCount, "corners", corners, "allPoints", len(allPoints))
 
-	return perimiterCount * len(allPoints), corners * len(allPoints)
+	return perimiterCount * len(allPoints), corners * len(allPoints), allPoints
 }
 
 type Corner struct {
@@ -123,9 +132,11 @@ func NewCorner(p []twod.Pos) *Corner {
 	return &Corner{Pos: p}
 }
 
-func main() {
-	log := logging.DefaultLogger()
-	scanner := bufio.NewScanner(os.Stdin)
+// price walks every region in the garden, summing the fence cost for part1
+// and the bulk discount cost for part2.
+func price(ctx context.Context, r io.Reader) (part1 int, part2 int, err error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
 
 	grid := make(Grid, 0)
 	for scanner.Scan() {
@@ -136,23 +147,56 @@ func main() {
 		}
 		grid = append(grid, row)
 	}
-	log.Infow("loaded grid", "grid", grid)
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+	log.Debugw("loaded grid", "grid", grid)
+
+	rend := twod.RendererFromContext(ctx)
+	var canvas *twod.Canvas
+	if rend.Enabled() && len(grid) > 0 {
+		canvas = twod.NewCanvas(len(grid), len(grid[0]))
+		canvas.Outline = color.Black
+		rend.Add(canvas)
+	}
 
-	part1 := 0
-	part2 := 0
+	regions := 0
 	for r, row := range grid {
 		for c := range row {
 			if grid[r][c] == PROCESSED {
 				continue // we've already processed this
 			}
-			log.Infow("processing", "r", r, "c", c, "val", grid[r][c])
-			cost, bulkCost := grid.CalculateFence(r, c)
+			log.Debugw("processing", "r", r, "c", c, "val", grid[r][c])
+			cost, bulkCost, region := grid.CalculateFence(ctx, r, c)
+			if canvas != nil {
+				for p := range region {
+					canvas.Fill(&p, twod.Distinct(regions))
+				}
+			}
+			regions++
 			part1 += cost
 			part2 += bulkCost
-			log.Infow("cost", "cost", cost, "bulkCost", bulkCost, "total", part1)
+			log.Debugw("cost", "cost", cost, "bulkCost", bulkCost, "total", part1)
 		}
 	}
+	return part1, part2, nil
+}
 
-	log.Infow("part1", "part1", part1)
-	log.Infow("part2", "part2", part2)
+// Part1 is the total price of fencing every region.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	part1, _, err := price(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return part1, nil
+}
+
+// Part2 is the total price with the bulk discount, which charges by the
+// number of sides instead of the perimeter.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	_, part2, err := price(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return part2, nil
 }
@@ -0,0 +1,104 @@
+package day13
+
+import (
+	"context"
+	"io"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/adventofcode/pkg/twod"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 13, Part1: Part1, Part2: Part2})
+}
+
+type Machine struct {
+	ButtonA twod.Pos
+	ButtonB twod.Pos
+	Prize   twod.Pos
+}
+
+func (m Machine) Solve(ctx context.Context, offset int64) int64 {
+	x1 := int64(m.ButtonA.Row)
+	y1 := int64(m.ButtonA.Col)
+	x2 := int64(m.ButtonB.Row)
+	y2 := int64(m.ButtonB.Col)
+
+	c := int64(m.Prize.Row) + offset
+	d := int64(m.Prize.Col) + offset
+
+	a := (c*y2 - d*x2) / (x1*y2 - x2*y1)
+	b := (d*x1 - c*y1) / (x1*y2 - x2*y1)
+
+	log := logging.FromContext(ctx)
+	log.Debugw("solving machine", "a", a, "b", b)
+
+	if a*x1+b*x2 == c && a*y1+b*y2 == d {
+		return 3*a + b
+	}
+	return 0
+}
+
+var (
+	buttonAPattern = parse.MustCompile("Button A: X+{row}, Y+{col}")
+	buttonBPattern = parse.MustCompile("Button B: X+{row}, Y+{col}")
+	prizePattern   = parse.MustCompile("Prize: X={row}, Y={col}")
+)
+
+// load reads the machines, each a section of three lines for the buttons
+// and the prize.
+func load(ctx context.Context, r io.Reader) ([]Machine, error) {
+	log := logging.FromContext(ctx)
+	sections, err := parse.Sections(r)
+	if err != nil {
+		return nil, err
+	}
+
+	machines := make([]Machine, 0, len(sections))
+	for _, lines := range sections {
+		if len(lines) != 3 {
+			return nil, lines[0].Errorf("machine has %d lines, want 3", len(lines))
+		}
+		var m Machine
+		if err := buttonAPattern.Match(lines[0], &m.ButtonA); err != nil {
+			return nil, err
+		}
+		if err := buttonBPattern.Match(lines[1], &m.ButtonB); err != nil {
+			return nil, err
+		}
+		if err := prizePattern.Match(lines[2], &m.Prize); err != nil {
+			return nil, err
+		}
+		machines = append(machines, m)
+	}
+	log.Debugw("loaded machines", "machines", machines)
+	return machines, nil
+}
+
+func tokens(ctx context.Context, r io.Reader, offset int64) (any, error) {
+	log := logging.FromContext(ctx)
+	machines, err := load(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for i, m := range machines {
+		ans := m.Solve(ctx, offset)
+		log.Debugw("machine solved", "i", i+1, "ans", ans)
+		total += ans
+	}
+	return total, nil
+}
+
+// Part1 is the fewest tokens needed to win every prize that can be won.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	return tokens(ctx, r, 0)
+}
+
+// Part2 moves every prize 10000000000000 further along both axes.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	return tokens(ctx, r, 10000000000000)
+}
@@ -0,0 +1,189 @@
+package day14
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/anim"
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/adventofcode/pkg/twod"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 14, Part1: Part1, Part2: Part2})
+}
+
+// palette colors the empty tiles and the robots in recorded frames.
+var palette = color.Palette{color.Black, color.RGBA{0x00, 0xcc, 0x00, 0xff}}
+
+type Robot struct {
+	Pos      *twod.Pos
+	Velocity *twod.Pos
+}
+
+func (r *Robot) Clone() *Robot {
+	return &Robot{
+		Pos:      r.Pos.Clone(),
+		Velocity: r.Velocity.Clone(),
+	}
+}
+
+func (r *Robot) Move(times int, width int, height int) {
+	for i := 0; i < times; i++ {
+		r.Pos.Add(r.Velocity)
+	}
+	r.Pos.Row = r.Pos.Row % height
+	if r.Pos.Row < 0 {
+		r.Pos.Row += height
+	}
+	r.Pos.Col = r.Pos.Col % width
+	if r.Pos.Col < 0 {
+		r.Pos.Col += width
+	}
+}
+
+func (r *Robot) Quadrant(width int, height int) int {
+	// Top left
+	if r.Pos.Row < height/2 && r.Pos.Col < width/2 {
+		return 1
+	}
+	// Top right
+	if r.Pos.Row < height/2 && r.Pos.Col > width/2 {
+		return 2
+	}
+	// Bottom left
+	if r.Pos.Row > height/2 && r.Pos.Col < width/2 {
+		return 3
+	}
+	if r.Pos.Row > height/2 && r.Pos.Col > width/2 {
+		return 4
+	}
+	return 0
+}
+
+// robotPattern is a robot's line, the x and y are the column and row.
+var robotPattern = parse.MustCompile("p={pos.col},{pos.row} v={velocity.col},{velocity.row}")
+
+// NewRobot parses a robot's position and velocity.
+func NewRobot(line parse.Field) (*Robot, error) {
+	robot := &Robot{}
+	if err := robotPattern.Match(line, robot); err != nil {
+		return nil, err
+	}
+	return robot, nil
+}
+
+// load reads the size of the space from the first line followed by the
+// robots.
+func load(ctx context.Context, r io.Reader) ([]*Robot, int, int, error) {
+	log := logging.FromContext(ctx)
+	lines, err := parse.Lines(r)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if len(lines) == 0 {
+		return nil, 0, 0, fmt.Errorf("no dimensions in the input")
+	}
+
+	dims, err := lines[0].SplitInts(",")
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if len(dims) != 2 {
+		return nil, 0, 0, lines[0].Errorf("invalid dimensions: %q", lines[0].Text)
+	}
+	height, width := dims[0], dims[1]
+
+	robots := make([]*Robot, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		log.Debugf("Line: %s", line)
+		robot, err := NewRobot(line)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		log.Debugw("loaded robot", "position", robot.Pos, "velocity", robot.Velocity)
+		robots = append(robots, robot)
+	}
+	return robots, width, height, nil
+}
+
+// Part1 is the safety factor after 100 seconds.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	robots, width, height, err := load(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	quads := make(map[int]int)
+	for _, robot := range robots {
+		robot.Move(100, width, height)
+		quad := robot.Quadrant(width, height)
+		quads[quad]++
+	}
+	log.Debugw("quads", "quads", quads)
+
+	total := 0
+	for _, v := range quads {
+		total += v
+	}
+	log.Debugw("robots", "in", len(robots), "out", total)
+	return quads[1] * quads[2] * quads[3] * quads[4], nil
+}
+
+// Part2 finds the first second where the robots draw a Christmas tree.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	log := logging.FromContext(ctx)
+	rec := anim.FromContext(ctx)
+	robots, width, height, err := load(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 1; i <= 10000; i++ {
+		for _, robot := range robots {
+			robot.Move(1, width, height)
+		}
+		picture, ok := Draw(robots, width, height)
+		if rec.Enabled() {
+			rec.Add(anim.FromText(picture, ".#", palette))
+		}
+		if ok {
+			log.Infof("Iter %d\n%s", i, picture)
+			return i, nil
+		}
+	}
+	return nil, fmt.Errorf("no tree found in 10000 seconds")
+}
+
+// Draw renders the robots and reports whether it looks like a tree, which
+// is assumed when a long line of robots is found.
+func Draw(robots []*Robot, width int, height int) (string, bool) {
+	out := strings.Builder{}
+
+	rMap := make(map[twod.Pos]int)
+	for _, r := range robots {
+		rMap[*r.Pos]++
+	}
+
+	for r := 0; r < height; r++ {
+		line := strings.Builder{}
+		for c := 0; c < width; c++ {
+			if rMap[twod.Pos{Row: r, Col: c}] > 0 {
+				line.WriteString("#")
+			} else {
+				line.WriteString(".")
+			}
+		}
+		out.WriteString(line.String())
+		out.WriteString("\n")
+	}
+
+	thisItr := out.String()
+	return thisItr, strings.Contains(thisItr, "########")
+}
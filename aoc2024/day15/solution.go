@@ -1,17 +1,24 @@
-package main
+package day15
 
 import (
 	"bufio"
-	"flag"
+	"context"
 	"fmt"
-	"os"
+	"image/color"
+	"io"
 	"strings"
 
+	"github.com/mikehelmick/adventofcode/pkg/anim"
 	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
 	"github.com/mikehelmick/adventofcode/pkg/twod"
 	"github.com/mikehelmick/go-functional/slice"
 )
 
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 15, Part1: Part1, Part2: Part2})
+}
+
 const (
 	WALL   = 0
 	EMPTY  = 1
@@ -23,6 +30,21 @@ const (
 
 type Grid [][]int
 
+// palette colors the walls, empty space, boxes and robot in recorded frames,
+// indexed by the cell values.
+var palette = color.Palette{
+	WALL:         color.RGBA{0x60, 0x60, 0x60, 0xff},
+	EMPTY:        color.Black,
+	OBJECT:       color.RGBA{0xcc, 0x99, 0x33, 0xff},
+	ROBOT:        color.RGBA{0xff, 0x33, 0x33, 0xff},
+	OBJECT_RIGHT: color.RGBA{0xcc, 0x99, 0x33, 0xff},
+}
+
+// Frame is the grid as a recorded frame.
+func (g Grid) Frame() anim.Frame {
+	return anim.FromGrid(g, palette, func(cell int) uint8 { return uint8(cell) })
+}
+
 func (g Grid) Write(p2 bool) string {
 	var s string
 	for _, row := range g {
@@ -166,12 +188,12 @@ func (g Grid) moveInternal(robot *twod.Pos, command string, doMove bool) *twod.P
 	return robot
 }
 
-func main() {
-	log := logging.DefaultLogger()
-	scanner := bufio.NewScanner(os.Stdin)
-
-	p2 := flag.Bool("p2", false, "run part 2")
-	flag.Parse()
+// warehouse runs the robot through its moves and sums the GPS coordinates of
+// the boxes. When wide is set, everything but the robot is twice as wide.
+func warehouse(ctx context.Context, r io.Reader, wide bool) (any, error) {
+	log := logging.FromContext(ctx)
+	rec := anim.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
 
 	// load grid
 	grid := make(Grid, 0)
@@ -187,60 +209,83 @@ func main() {
 			switch c {
 			case '#':
 				row = append(row, WALL)
-				if *p2 {
+				if wide {
 					row = append(row, WALL)
 				}
 			case '.':
 				row = append(row, EMPTY)
-				if *p2 {
+				if wide {
 					row = append(row, EMPTY)
 				}
 			case 'O':
 				row = append(row, OBJECT)
-				if *p2 {
+				if wide {
 					row = append(row, OBJECT_RIGHT)
 				}
 			case '@':
 				row = append(row, ROBOT)
 				robot = &twod.Pos{Row: len(grid), Col: w}
-				if *p2 {
+				if wide {
 					row = append(row, EMPTY)
 				}
 			}
 			w++
-			if *p2 {
+			if wide {
 				w++
 			}
 		}
 		grid = append(grid, row)
 	}
-	log.Info("loaded grid", "robot", robot)
-	fmt.Printf("LOADED\n%s", grid.Write(*p2))
+	if robot == nil {
+		return nil, fmt.Errorf("no robot in the warehouse")
+	}
+	log.Debugw("loaded grid", "robot", robot)
+	debug := logging.IsDebug()
+	if debug {
+		log.Debugf("LOADED\n%s", grid.Write(wide))
+	}
 
 	instr := ""
 	for scanner.Scan() {
 		instr += scanner.Text()
 	}
-	log.Infow("loaded instructions", "instructions", instr)
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	log.Debugw("loaded instructions", "instructions", instr)
 	instr = strings.ReplaceAll(instr, "v", "V")
 
+	if rec.Enabled() {
+		rec.Add(grid.Frame())
+	}
 	for _, c := range instr {
-		log.Infow("moving", "robot", robot, "command", string(c))
+		log.Debugw("moving", "robot", robot, "command", string(c))
 		robot = grid.Move(robot, string(c), true)
-		fmt.Printf("MOVED %s\n%s\n\n", string(c), grid.Write(*p2))
+		if debug {
+			log.Debugf("MOVED %s\n%s\n", string(c), grid.Write(wide))
+		}
+		if rec.Enabled() {
+			rec.Add(grid.Frame())
+		}
 	}
 
-	part1 := 0
+	answer := 0
 	for r, row := range grid {
 		for c, cell := range row {
 			if cell == OBJECT {
-				part1 += (100*r + c)
+				answer += (100*r + c)
 			}
 		}
 	}
-	part := "part1"
-	if *p2 {
-		part = "part2"
-	}
-	log.Infow(part, "answer", part1)
+	return answer, nil
+}
+
+// Part1 sums the box coordinates after the robot finishes moving.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	return warehouse(ctx, r, false)
+}
+
+// Part2 does the same in the scaled up warehouse.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	return warehouse(ctx, r, true)
 }
@@ -0,0 +1,100 @@
+package day19
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/memo"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"github.com/mikehelmick/go-functional/slice"
+)
+
+func init() {
+	solver.Register(&solver.Day{Year: 2024, Day: 19, Part1: Part1, Part2: Part2})
+}
+
+// arrange counts how many of the designs can be made from the towels and
+// the total number of ways to make them.
+func arrange(ctx context.Context, r io.Reader) (possible int, ways int, err error) {
+	log := logging.FromContext(ctx)
+	scanner := bufio.NewScanner(r)
+
+	scanner.Scan()
+	line := scanner.Text()
+
+	towels := strings.Split(line, ",")
+	towels = slice.Map(towels, func(pattern string) string {
+		return strings.TrimSpace(pattern)
+	})
+	log.Debugw("loaded towels", "towels", towels)
+
+	patterns := make([]string, 0, 100)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, err
+	}
+	log.Debugw("loaded patterns", "patterns", patterns)
+
+	matchPattern, cache := memo.Recursive(memo.Options{}, func(self func(string) int, pattern string) int {
+		return canMatchPattern(self, towels, pattern)
+	})
+	for _, pattern := range patterns {
+		log.Debugw("checking pattern", "pattern", pattern)
+		matches := matchPattern(pattern)
+		if matches > 0 {
+			log.Debugw("pattern matches", "pattern", pattern)
+			ways += matches
+			possible++
+		} else {
+			log.Debugw("pattern does not match", "pattern", pattern)
+		}
+
+	}
+	log.Debugw("pattern cache", "size", cache.Len(), "stats", cache.Stats().String())
+	return possible, ways, nil
+}
+
+// Part1 counts the designs that are possible.
+func Part1(ctx context.Context, r io.Reader) (any, error) {
+	possible, _, err := arrange(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return possible, nil
+}
+
+// Part2 adds up every way each design could be made.
+func Part2(ctx context.Context, r io.Reader) (any, error) {
+	_, ways, err := arrange(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return ways, nil
+}
+
+// canMatchPattern counts the ways the towels can make the pattern, using
+// match for the rest of the pattern after each towel.
+func canMatchPattern(match func(string) int, towels []string, pattern string) int {
+	if pattern == "" {
+		return 0
+	}
+
+	sum := 0
+	for _, towel := range towels {
+		if pattern == towel {
+			sum++
+		} else if strings.HasPrefix(pattern, towel) {
+			sum += match(strings.TrimPrefix(pattern, towel))
+		}
+	}
+	return sum
+}
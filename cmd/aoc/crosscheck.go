@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	_ "github.com/mikehelmick/adventofcode/aoc2023/all"
+	_ "github.com/mikehelmick/adventofcode/aoc2024/all"
+	"github.com/mikehelmick/adventofcode/pkg/config"
+	"github.com/mikehelmick/adventofcode/pkg/launcher"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+var crosscheckCmd = &command{
+	name:  "crosscheck",
+	usage: "run every implementation of a day on the same inputs and compare the answers",
+	run:   runCrosscheck,
+}
+
+// errDisagree is returned when any implementations disagree so that the
+// command exits with an error.
+var errDisagree = errors.New("implementations disagree")
+
+func runCrosscheck(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("crosscheck", flag.ContinueOnError)
+	var df dayFlags
+	df.register(fs, cfg)
+	all := fs.Bool("all", false, "--all to check every day of the year that has alternate implementations")
+	var sel launcher.Selection
+	fs.IntVar(&sel.Example, "e", 0, "-e N to run exampleN.txt")
+	fs.StringVar(&sel.Path, "i", "", "-i path or glob of the inputs to run, defaults to the day's example*.txt")
+	partFlag := fs.String("part", "both", "--part 1|2|both to select which parts to check")
+	ctx, cancel, err := parseFlags(ctx, cfg, fs, args)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	parts, err := solver.ParseParts(*partFlag)
+	if err != nil {
+		return err
+	}
+	if sel.Example == 0 && sel.Path == "" {
+		sel.Examples = true
+	}
+
+	var days []*solver.Day
+	if *all {
+		for _, d := range solver.Days(df.year) {
+			if len(d.Alternates) > 0 {
+				days = append(days, d)
+			}
+		}
+	} else {
+		d, ok := solver.Lookup(df.year, df.day)
+		if !ok {
+			return fmt.Errorf("no solution for %d day %d", df.year, df.day)
+		}
+		days = append(days, d)
+	}
+
+	// Stdin can only be read once, so every day checks the same copy.
+	var stdin []*launcher.Input
+	if sel.Path == launcher.Stdin {
+		if stdin, err = sel.Resolve("", os.Stdin); err != nil {
+			return err
+		}
+		defer launcher.Close(stdin)
+	}
+
+	checks, failed := 0, 0
+	for _, d := range days {
+		inputs := stdin
+		if inputs == nil {
+			dir := df.path(cfg)
+			if *all {
+				dir = cfg.DayDir(d.Year, d.Day)
+			}
+			if inputs, err = sel.Resolve(dir, os.Stdin); err != nil {
+				return fmt.Errorf("%v: %w", d, err)
+			}
+			defer launcher.Close(inputs)
+		}
+		for _, in := range inputs {
+			for _, part := range parts {
+				c := d.CrossCheck(ctx, part, in.Open)
+				if len(c.Results) == 0 {
+					continue
+				}
+				checks++
+				if !c.Agree() {
+					failed++
+				}
+				if err := writeCheck(d, in, c); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	fmt.Printf("\n%d checks, %d disagreements\n", checks, failed)
+	if failed > 0 {
+		return errDisagree
+	}
+	return nil
+}
+
+// writeCheck prints the answer and time of each implementation, marking
+// those that differ from the default.
+func writeCheck(d *solver.Day, in *launcher.Input, c *solver.Check) error {
+	fmt.Printf("%v %s part %d\n", d, filepath.Base(in.Name), c.Part)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i, res := range c.Results {
+		answer := fmt.Sprint(res.Answer)
+		if res.Err != nil {
+			answer = "error: " + res.Err.Error()
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%v", res.Impl, answer, res.Duration)
+		if c.Differs(i) {
+			fmt.Fprintf(tw, "\t<- differs")
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mikehelmick/adventofcode/pkg/client"
+	"github.com/mikehelmick/adventofcode/pkg/config"
+	"github.com/mikehelmick/adventofcode/pkg/puzzle"
+)
+
+// ReadmeFile is where the rendered puzzle description is stored.
+const ReadmeFile = "README.md"
+
+var describeCmd = &command{
+	name:  "describe",
+	usage: "fetch and cache a puzzle description as README.md in the day directory",
+	run:   runDescribe,
+}
+
+func runDescribe(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("describe", flag.ContinueOnError)
+	var df dayFlags
+	df.register(fs, cfg)
+	refresh := fs.Bool("refresh", false, "--refresh to fetch the page even if it is cached")
+	ctx, cancel, err := parseFlags(ctx, cfg, fs, args)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	session, err := cfg.Session()
+	if err != nil {
+		return err
+	}
+	c := client.New(session)
+
+	dir := df.path(cfg)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := c.CachedPuzzle(ctx, df.year, df.day, filepath.Join(dir, puzzle.PageFile), *refresh)
+	if err != nil {
+		return err
+	}
+	page, err := puzzle.Parse(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if len(page.Parts) < 2 {
+		fmt.Println("part 2 is not unlocked yet, run again once part 1 is solved")
+	}
+
+	u, err := c.PuzzleURL(df.year, df.day)
+	if err != nil {
+		return err
+	}
+	md := page.Markdown(u) + fmt.Sprintf("\nFrom [%s](%s)\n", u, u)
+	path := filepath.Join(dir, ReadmeFile)
+	if err := os.WriteFile(path, []byte(md), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %s\n", path)
+	return nil
+}
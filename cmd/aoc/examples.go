@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mikehelmick/adventofcode/pkg/config"
+	"github.com/mikehelmick/adventofcode/pkg/puzzle"
+)
+
+var examplesCmd = &command{
+	name:  "examples",
+	usage: "write exampleN.txt and expected answers from a cached puzzle page",
+	run:   runExamples,
+}
+
+func runExamples(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("examples", flag.ContinueOnError)
+	var df dayFlags
+	df.register(fs, cfg)
+	page := fs.String("page", "", "-page path to the cached puzzle page, defaults to puzzle.html in the day directory")
+	force := fs.Bool("force", false, "--force to overwrite existing example files")
+	_, cancel, err := parseFlags(ctx, cfg, fs, args)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	dir := df.path(cfg)
+	pagePath := *page
+	if pagePath == "" {
+		pagePath = filepath.Join(dir, puzzle.PageFile)
+	}
+
+	f, err := os.Open(pagePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	parsed, err := puzzle.Parse(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", pagePath, err)
+	}
+
+	files, expected := parsed.ExampleFiles()
+	for _, ex := range files {
+		path := filepath.Join(dir, ex.Name)
+		if _, err := os.Stat(path); err == nil && !*force {
+			fmt.Printf("skipping %s, already exists\n", path)
+			continue
+		}
+		if err := os.WriteFile(path, []byte(ex.Content), 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s\n", path)
+	}
+
+	answersPath := filepath.Join(dir, puzzle.AnswersFile)
+	answers, err := puzzle.LoadAnswers(answersPath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", answersPath, err)
+	}
+	for _, a := range expected {
+		answers = answers.Set(a.Input, a.Part, a.Value)
+	}
+	out, err := os.Create(answersPath)
+	if err != nil {
+		return err
+	}
+	if err := answers.Write(out); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %d answers to %s\n", len(answers), answersPath)
+	return nil
+}
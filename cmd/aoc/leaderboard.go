@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mikehelmick/adventofcode/pkg/client"
+	"github.com/mikehelmick/adventofcode/pkg/config"
+	"github.com/mikehelmick/adventofcode/pkg/leaderboard"
+)
+
+var leaderboardCmd = &command{
+	name:  "leaderboard",
+	usage: "report on a private leaderboard: aoc leaderboard [flags] <id>",
+	run:   runLeaderboard,
+}
+
+func runLeaderboard(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("leaderboard", flag.ContinueOnError)
+	year := fs.Int("y", cfg.DefaultYear, "-y YYYY for the event year")
+	cacheDir := fs.String("cache-dir", defaultCacheDir(), "-cache-dir path where leaderboard responses are cached")
+	ctx, cancel, err := parseFlags(ctx, cfg, fs, args)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected a leaderboard id")
+	}
+	id := fs.Arg(0)
+
+	session, err := cfg.Session()
+	if err != nil {
+		return err
+	}
+	c := client.New(session)
+
+	path := filepath.Join(*cacheDir, "leaderboard", fmt.Sprintf("%d-%s.json", *year, id))
+	data, err := c.Leaderboard(ctx, *year, id, path)
+	if err != nil {
+		return err
+	}
+	lb, err := leaderboard.Parse(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	report, err := leaderboard.NewReport(lb)
+	if err != nil {
+		return err
+	}
+	return report.Write(os.Stdout, cfg.OutputFormat)
+}
+
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "aoc")
+}
@@ -0,0 +1,120 @@
+// Command aoc is a collection of helpers for working on Advent of Code
+// puzzles in this repository.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/config"
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, cfg *config.Config, args []string) error
+}
+
+var commands = []*command{
+	crosscheckCmd,
+	describeCmd,
+	examplesCmd,
+	leaderboardCmd,
+	playCmd,
+	serveCmd,
+	statusCmd,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name := os.Args[1]
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "aoc: loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		if err := c.run(context.Background(), cfg, os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "aoc %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: aoc <command> [flags]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", c.name, c.usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nsettings are read from %s\n", config.Path())
+}
+
+// parseFlags adds the shared settings to fs and parses args. The returned
+// context carries a logger at the configured level and the configured
+// timeout, call cancel when done to release both.
+func parseFlags(ctx context.Context, cfg *config.Config, fs *flag.FlagSet, args []string) (context.Context, context.CancelFunc, error) {
+	ctx, closeLog, err := parseSettings(ctx, cfg, fs, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, time.Duration(cfg.Timeout))
+		return ctx, func() {
+			cancel()
+			closeLog()
+		}, nil
+	}
+	return ctx, closeLog, nil
+}
+
+// parseSettings is parseFlags without the timeout, for commands that don't
+// run solvers, like interactive playback. The logger is also made the
+// default logger, call closeLog when done to flush it.
+func parseSettings(ctx context.Context, cfg *config.Config, fs *flag.FlagSet, args []string) (_ context.Context, closeLog func(), _ error) {
+	cfg.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	log, closeLog, err := cfg.Logger()
+	if err != nil {
+		return nil, nil, err
+	}
+	logging.SetDefault(log)
+	return logging.WithLogger(ctx, log), closeLog, nil
+}
+
+// dayFlags are the flags shared by commands that operate on a single day.
+type dayFlags struct {
+	year int
+	day  int
+	dir  string
+}
+
+func (d *dayFlags) register(fs *flag.FlagSet, cfg *config.Config) {
+	fs.IntVar(&d.year, "y", cfg.DefaultYear, "-y YYYY for the puzzle year")
+	fs.IntVar(&d.day, "d", 1, "-d X for day X")
+	fs.StringVar(&d.dir, "dir", "", "-dir path to override the day directory")
+}
+
+// path returns the day directory from the config unless overridden.
+func (d *dayFlags) path(cfg *config.Config) string {
+	if d.dir != "" {
+		return d.dir
+	}
+	return cfg.DayDir(d.year, d.day)
+}
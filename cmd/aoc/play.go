@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/anim"
+	"github.com/mikehelmick/adventofcode/pkg/config"
+	"golang.org/x/term"
+)
+
+var playCmd = &command{
+	name:  "play",
+	usage: "replay frames recorded with the launcher's -frames flag in the terminal",
+	run:   runPlay,
+}
+
+func runPlay(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("play", flag.ContinueOnError)
+	fps := fs.Int("fps", 10, "-fps N frames per second, + and - change it while playing")
+	paused := fs.Bool("paused", false, "-paused to start paused on the first frame")
+	// The run timeout is for solvers, playback lasts as long as the user
+	// watches.
+	ctx, closeLog, err := parseSettings(ctx, cfg, fs, args)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+	if fs.NArg() != 1 {
+		return errors.New("usage: aoc play [-fps N] file.frames")
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	frames, err := anim.Decode(f)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+	if len(frames) == 0 {
+		return fmt.Errorf("%s: no frames", fs.Arg(0))
+	}
+
+	p := anim.NewPlayer(frames, *fps)
+	p.Paused = *paused
+
+	// Keys need a terminal, otherwise the frames are played through once.
+	keys := make(chan string)
+	stdin := int(os.Stdin.Fd())
+	if term.IsTerminal(stdin) {
+		state, err := term.MakeRaw(stdin)
+		if err != nil {
+			return err
+		}
+		defer term.Restore(stdin, state)
+		go readKeys(keys)
+	} else {
+		p.Paused = false
+	}
+
+	fmt.Print(anim.AnsiHideCursor)
+	defer fmt.Print(anim.AnsiShowCursor + "\r\n")
+
+	ticker := time.NewTicker(time.Second / time.Duration(p.FPS))
+	defer ticker.Stop()
+	if err := p.Draw(os.Stdout); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case key, ok := <-keys:
+			if !ok || p.Handle(key) {
+				return nil
+			}
+			ticker.Reset(time.Second / time.Duration(p.FPS))
+		case <-ticker.C:
+			if !p.Tick() {
+				if p.Paused && !term.IsTerminal(stdin) {
+					return nil
+				}
+				continue
+			}
+		}
+		if err := p.Draw(os.Stdout); err != nil {
+			return err
+		}
+	}
+}
+
+// readKeys sends keys read from stdin until it is closed.
+func readKeys(keys chan<- string) {
+	defer close(keys)
+	buf := make([]byte, 16)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, k := range anim.ParseKeys(buf[:n]) {
+			keys <- k
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/config"
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/server"
+)
+
+var serveCmd = &command{
+	name:  "serve",
+	usage: "serve a local HTTP JSON API for running the solvers",
+	run:   runServe,
+}
+
+func runServe(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "127.0.0.1:8080", "-addr host:port to listen on")
+	var opts server.Options
+	fs.IntVar(&opts.MaxConcurrent, "max-concurrent", 0, "-max-concurrent N parts running at once, the number of CPUs when 0")
+	fs.Int64Var(&opts.MaxInput, "max-input", 10<<20, "-max-input N bytes accepted as input")
+	fs.IntVar(&opts.MaxLogs, "max-logs", 1000, "-max-logs N log entries kept for each run, later ones are dropped")
+	ctx, cancel, err := parseFlags(ctx, cfg, fs, args)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	// The timeout applies to each run rather than to the server.
+	ctx = context.WithoutCancel(ctx)
+	opts.Timeout = time.Duration(cfg.Timeout)
+
+	log := logging.FromContext(ctx)
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(ctx, opts),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	log.Infow("serving", "addr", *addr, "timeout", opts.Timeout)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
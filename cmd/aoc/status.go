@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/mikehelmick/adventofcode/pkg/config"
+	"github.com/mikehelmick/adventofcode/pkg/progress"
+)
+
+var statusCmd = &command{
+	name:  "status",
+	usage: "show which days of a year are solved and what they are missing",
+	run:   runStatus,
+}
+
+func runStatus(ctx context.Context, cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	year := fs.Int("y", cfg.DefaultYear, "-y YYYY for the event year")
+	_, cancel, err := parseFlags(ctx, cfg, fs, args)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	y, err := progress.Scan(*year, func(day int) string {
+		return cfg.DayDir(*year, day)
+	})
+	if err != nil {
+		return err
+	}
+	return y.Write(os.Stdout, statusFormat(fs, cfg.OutputFormat))
+}
+
+// statusFormat returns the format to write progress in. The configured
+// format is shared with other commands and may be one progress doesn't
+// support, like csv for leaderboard reports, so it falls back to text
+// unless it was given with -format.
+func statusFormat(fs *flag.FlagSet, format string) string {
+	switch format {
+	case progress.FormatText, progress.FormatJSON:
+		return format
+	}
+	explicit := false
+	fs.Visit(func(f *flag.Flag) {
+		explicit = explicit || f.Name == "format"
+	})
+	if explicit {
+		return format
+	}
+	return progress.FormatText
+}
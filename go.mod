@@ -6,7 +6,13 @@ require (
 	github.com/mikehelmick/go-functional v0.3.0
 	github.com/yourbasic/graph v0.0.0-20210606180040-8ecfec1c2869
 	go.uber.org/zap v1.27.0
+	golang.org/x/image v0.25.0
+	golang.org/x/net v0.38.0
+	golang.org/x/term v0.30.0
 	gonum.org/v1/gonum v0.15.1
 )
 
-require go.uber.org/multierr v1.11.0 // indirect
+require (
+	go.uber.org/multierr v1.11.0 // indirect
+	golang.org/x/sys v0.31.0 // indirect
+)
@@ -0,0 +1,226 @@
+// Package anim records the frames of a simulation so they can be exported
+// as an animated GIF.
+//
+// Solvers find the recorder with FromContext and push a frame for each step.
+// Without a recorder in the context FromContext returns nil, which is safe to
+// use and records nothing, so solvers should check Enabled before doing any
+// work to build a frame.
+package anim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type contextKey string
+
+const recorderKey = contextKey("recorder")
+
+// Frame is one step of a simulation.
+type Frame struct {
+	// Cells are indexes into the palette, by row and then column.
+	Cells [][]uint8
+	// Palette has the colors of the cells, at most 256.
+	Palette color.Palette
+}
+
+// FromText converts text, one row per line, to a frame. The color of each
+// rune is the palette entry at its position in runes, and runes not in
+// runes are the first color.
+func FromText(s string, runes string, palette color.Palette) Frame {
+	index := make(map[rune]uint8, len(runes))
+	for i, r := range []rune(runes) {
+		index[r] = uint8(i)
+	}
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	cells := make([][]uint8, len(lines))
+	for r, line := range lines {
+		cells[r] = make([]uint8, 0, len(line))
+		for _, c := range line {
+			cells[r] = append(cells[r], index[c])
+		}
+	}
+	return Frame{Cells: cells, Palette: palette}
+}
+
+// FromGrid converts a grid to a frame using index to pick each cell's color
+// from the palette.
+func FromGrid[T any](g [][]T, palette color.Palette, index func(T) uint8) Frame {
+	cells := make([][]uint8, len(g))
+	for r, row := range g {
+		cells[r] = make([]uint8, len(row))
+		for c, cell := range row {
+			cells[r][c] = index(cell)
+		}
+	}
+	return Frame{Cells: cells, Palette: palette}
+}
+
+// Set colors one cell, growing the frame if needed.
+func (f *Frame) Set(row, col int, idx uint8) {
+	for len(f.Cells) <= row {
+		f.Cells = append(f.Cells, nil)
+	}
+	for len(f.Cells[row]) <= col {
+		f.Cells[row] = append(f.Cells[row], 0)
+	}
+	f.Cells[row][col] = idx
+}
+
+func (f Frame) clone() Frame {
+	cells := make([][]uint8, len(f.Cells))
+	for r, row := range f.Cells {
+		cells[r] = slices.Clone(row)
+	}
+	return Frame{Cells: cells, Palette: f.Palette}
+}
+
+// Options control which frames are kept and how they are drawn.
+type Options struct {
+	// Every keeps only every Every-th frame, all of them when 1 or less.
+	Every int
+	// MaxFrames caps how many frames are kept, zero is no limit.
+	MaxFrames int
+	// Scale is the size of each cell in pixels, 1 when zero.
+	Scale int
+	// Delay is the time between frames in 100ths of a second.
+	Delay int
+}
+
+// Recorder collects frames. The last frame added is always kept, even if
+// it would be skipped or is past MaxFrames, so the animation ends on the
+// final state of the simulation. A solver that timed out may still be
+// adding frames while they are saved, so access is guarded by mu.
+type Recorder struct {
+	opts Options
+
+	mu      sync.Mutex
+	frames  []Frame
+	last    Frame
+	pending bool
+	seen    int
+}
+
+// NewRecorder returns an empty recorder.
+func NewRecorder(opts Options) *Recorder {
+	return &Recorder{opts: opts}
+}
+
+// WithRecorder returns a context that carries the recorder.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey, r)
+}
+
+// FromContext returns the recorder in the context, or nil if there isn't
+// one.
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(recorderKey).(*Recorder)
+	return r
+}
+
+// Enabled is false for a nil recorder.
+func (r *Recorder) Enabled() bool {
+	return r != nil
+}
+
+// Add records a copy of the frame, unless it is skipped.
+func (r *Recorder) Add(f Frame) {
+	if r == nil {
+		return
+	}
+	f = f.clone()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seen++
+	r.last = f
+	r.pending = true
+
+	if every := r.opts.Every; every > 1 && (r.seen-1)%every != 0 {
+		return
+	}
+	// Leave room for the last frame.
+	if r.opts.MaxFrames > 0 && len(r.frames) >= r.opts.MaxFrames-1 {
+		return
+	}
+	r.frames = append(r.frames, r.last)
+	r.pending = false
+}
+
+// Seen is the number of frames added, including those that were skipped.
+func (r *Recorder) Seen() int {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.seen
+}
+
+// Frames returns the frames that are kept.
+func (r *Recorder) Frames() []Frame {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending {
+		return append(slices.Clip(r.frames), r.last)
+	}
+	return slices.Clip(r.frames)
+}
+
+// WriteGIF encodes the kept frames as an animated GIF. Every frame is drawn
+// at the size of the largest one.
+func (r *Recorder) WriteGIF(w io.Writer) error {
+	frames := r.Frames()
+	if len(frames) == 0 {
+		return errors.New("no frames recorded")
+	}
+	scale := max(r.opts.Scale, 1)
+
+	rows, cols := 0, 0
+	for _, f := range frames {
+		rows = max(rows, len(f.Cells))
+		for _, row := range f.Cells {
+			cols = max(cols, len(row))
+		}
+	}
+	if rows == 0 || cols == 0 {
+		return errors.New("frames are empty")
+	}
+	bounds := image.Rect(0, 0, cols*scale, rows*scale)
+
+	out := &gif.GIF{
+		Config: image.Config{ColorModel: frames[0].Palette, Width: bounds.Dx(), Height: bounds.Dy()},
+	}
+	for i, f := range frames {
+		if n := len(f.Palette); n == 0 || n > 256 {
+			return fmt.Errorf("frame %d: palette has %d colors, want 1 to 256", i, n)
+		}
+		img := image.NewPaletted(bounds, f.Palette)
+		for y, row := range f.Cells {
+			for x, idx := range row {
+				if int(idx) >= len(f.Palette) {
+					return fmt.Errorf("frame %d: color %d at %d,%d is not in the palette", i, idx, y, x)
+				}
+				for dy := 0; dy < scale; dy++ {
+					off := img.PixOffset(x*scale, y*scale+dy)
+					for dx := 0; dx < scale; dx++ {
+						img.Pix[off+dx] = idx
+					}
+				}
+			}
+		}
+		out.Image = append(out.Image, img)
+		out.Delay = append(out.Delay, r.opts.Delay)
+	}
+	return gif.EncodeAll(w, out)
+}
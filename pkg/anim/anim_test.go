@@ -0,0 +1,92 @@
+package anim_test
+
+import (
+	"bytes"
+	"context"
+	"image/color"
+	"image/gif"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/anim"
+)
+
+var palette = color.Palette{color.Black, color.White}
+
+func TestRecorder(t *testing.T) {
+	var none *anim.Recorder
+	none.Add(anim.Frame{Cells: [][]uint8{{1}}, Palette: palette})
+	if none.Enabled() || anim.FromContext(context.Background()) != nil {
+		t.Errorf("nil recorder should be disabled")
+	}
+
+	r := anim.NewRecorder(anim.Options{Every: 3, MaxFrames: 3})
+	ctx := anim.WithRecorder(context.Background(), r)
+	for i := 0; i < 10; i++ {
+		anim.FromContext(ctx).Add(anim.Frame{Cells: [][]uint8{{uint8(i % 2)}, {uint8(i)}}, Palette: palette})
+	}
+
+	// Frames 0 and 3 are kept, then the cap leaves room for the last one.
+	frames := r.Frames()
+	if len(frames) != 3 || r.Seen() != 10 {
+		t.Fatalf("frames, want: 3 of 10 got: %v of %v", len(frames), r.Seen())
+	}
+	for i, want := range []uint8{0, 3, 9} {
+		if got := frames[i].Cells[1][0]; got != want {
+			t.Errorf("frame %d, want: %v got: %v", i, want, got)
+		}
+	}
+}
+
+func TestWriteGIF(t *testing.T) {
+	r := anim.NewRecorder(anim.Options{Scale: 2, Delay: 10})
+	r.Add(anim.FromText("#.\n.#\n", ".#", palette))
+	r.Add(anim.FromText(".#.", ".#", palette))
+
+	var buf bytes.Buffer
+	if err := r.WriteGIF(&buf); err != nil {
+		t.Fatal(err)
+	}
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Image) != 2 || g.Config.Width != 6 || g.Config.Height != 4 {
+		t.Fatalf("wrong gif, want: 2 frames of 6x4 got: %d of %dx%d", len(g.Image), g.Config.Width, g.Config.Height)
+	}
+	if got := g.Image[0].ColorIndexAt(1, 1); got != 1 {
+		t.Errorf("scaled cell, want: 1 got: %v", got)
+	}
+	if got := g.Image[0].ColorIndexAt(2, 0); got != 0 {
+		t.Errorf("next cell, want: 0 got: %v", got)
+	}
+
+	bad := anim.NewRecorder(anim.Options{})
+	bad.Add(anim.Frame{Cells: [][]uint8{{2}}, Palette: palette})
+	if err := bad.WriteGIF(&buf); err == nil {
+		t.Errorf("expected error for color outside the palette")
+	}
+}
+
+func TestRecorderConcurrent(t *testing.T) {
+	// A solver that timed out keeps adding frames while they are saved.
+	r := anim.NewRecorder(anim.Options{Every: 3, MaxFrames: 50})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			r.Add(anim.Frame{Cells: [][]uint8{{uint8(i % 2)}}, Palette: palette})
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		r.Seen()
+		if len(r.Frames()) > 0 {
+			if err := r.WriteGIF(&bytes.Buffer{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	<-done
+	if r.Seen() != 1000 {
+		t.Errorf("seen, want: 1000 got: %v", r.Seen())
+	}
+}
@@ -0,0 +1,143 @@
+package anim
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The frames file is text so it can be read and diffed. It starts with the
+// header line, then each frame is a "frame" line followed by one line per
+// row with a symbol per cell. A "palette" line of hex colors comes before
+// the first frame and again whenever the palette changes:
+//
+//	aoc frames 1
+//	palette 000000 606060 ff3333
+//	frame
+//	1111
+//	1021
+//	1111
+//
+// Blank lines and lines starting with # are ignored.
+const fileHeader = "aoc frames 1"
+
+// symbols are the cell symbols in palette order, so frames written to a
+// file can have at most len(symbols) colors.
+const symbols = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// Encode writes the frames in the frames file format.
+func Encode(w io.Writer, frames []Frame) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, fileHeader)
+	var palette color.Palette
+	for i, f := range frames {
+		if len(f.Palette) > len(symbols) {
+			return fmt.Errorf("frame %d: palette has %d colors, a file holds at most %d", i, len(f.Palette), len(symbols))
+		}
+		if i == 0 || !slices.Equal(f.Palette, palette) {
+			palette = f.Palette
+			fmt.Fprint(bw, "palette")
+			for _, c := range palette {
+				r, g, b, _ := c.RGBA()
+				fmt.Fprintf(bw, " %02x%02x%02x", r>>8, g>>8, b>>8)
+			}
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintln(bw, "frame")
+		for _, row := range f.Cells {
+			line := make([]byte, len(row))
+			for c, idx := range row {
+				if int(idx) >= len(palette) {
+					return fmt.Errorf("frame %d: color %d is not in the palette", i, idx)
+				}
+				line[c] = symbols[idx]
+			}
+			bw.Write(line)
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+// Decode reads frames written by Encode.
+func Decode(r io.Reader) ([]Frame, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	line := 0
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+
+	var (
+		frames  []Frame
+		palette color.Palette
+		header  bool
+	)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if !header {
+			if text != fileHeader {
+				return nil, fail("not a frames file, want %q", fileHeader)
+			}
+			header = true
+			continue
+		}
+
+		switch {
+		case text == "frame":
+			if palette == nil {
+				return nil, fail("frame before palette")
+			}
+			frames = append(frames, Frame{Palette: palette})
+		case strings.HasPrefix(text, "palette"):
+			fields := strings.Fields(text)[1:]
+			if len(fields) == 0 || len(fields) > len(symbols) {
+				return nil, fail("palette has %d colors, want 1 to %d", len(fields), len(symbols))
+			}
+			palette = make(color.Palette, 0, len(fields))
+			for _, f := range fields {
+				v, err := strconv.ParseUint(f, 16, 32)
+				if err != nil || len(f) != 6 {
+					return nil, fail("invalid color %q, want rrggbb", f)
+				}
+				palette = append(palette, color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xff})
+			}
+		default:
+			if len(frames) == 0 {
+				return nil, fail("row before the first frame")
+			}
+			f := &frames[len(frames)-1]
+			row := make([]uint8, len(text))
+			for c := 0; c < len(text); c++ {
+				idx := strings.IndexByte(symbols, text[c])
+				if idx < 0 || idx >= len(f.Palette) {
+					return nil, fail("column %d: %q is not in the palette", c+1, text[c])
+				}
+				row[c] = uint8(idx)
+			}
+			f.Cells = append(f.Cells, row)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !header {
+		return nil, errors.New("empty frames file")
+	}
+	return frames, nil
+}
+
+// WriteFrames writes the kept frames in the frames file format.
+func (r *Recorder) WriteFrames(w io.Writer) error {
+	return Encode(w, r.Frames())
+}
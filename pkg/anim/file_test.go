@@ -0,0 +1,68 @@
+package anim_test
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/anim"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	other := color.Palette{color.RGBA{0x12, 0x34, 0x56, 0xff}, color.White, color.Black}
+	frames := []anim.Frame{
+		anim.FromText("#.\n.#", ".#", palette),
+		anim.FromText("..\n##", ".#", palette),
+		anim.FromText("ab\nc", "abc", other),
+	}
+
+	var buf bytes.Buffer
+	if err := anim.Encode(&buf, frames); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(buf.String(), "palette"); got != 2 {
+		t.Errorf("palette lines, want: 2 got: %v\n%s", got, buf.String())
+	}
+
+	got, err := anim.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(frames) {
+		t.Fatalf("frames, want: %v got: %v", len(frames), len(got))
+	}
+	for i, f := range frames {
+		if !equalCells(f.Cells, got[i].Cells) {
+			t.Errorf("frame %d cells, want: %v got: %v", i, f.Cells, got[i].Cells)
+		}
+	}
+	if r, g, b, _ := got[2].Palette[0].RGBA(); r>>8 != 0x12 || g>>8 != 0x34 || b>>8 != 0x56 {
+		t.Errorf("color, want: 123456 got: %02x%02x%02x", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	cases := map[string]string{
+		"header":  "frames\n",
+		"palette": "aoc frames 1\nframe\n01\n",
+		"color":   "aoc frames 1\npalette 000000 ffffff\nframe\n02\n",
+	}
+	for name, in := range cases {
+		if _, err := anim.Decode(strings.NewReader(in)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func equalCells(a, b [][]uint8) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for r := range a {
+		if !bytes.Equal(a[r], b[r]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package anim
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Keys understood by Player.Handle, as returned by ParseKeys.
+const (
+	KeyPlay  = "space"
+	KeyNext  = "right"
+	KeyPrev  = "left"
+	KeyStart = "home"
+	KeyEnd   = "end"
+	KeyQuit  = "q"
+	KeyFast  = "+"
+	KeySlow  = "-"
+)
+
+// ANSI escape codes used by the player.
+const (
+	ansiHome       = "\x1b[H"
+	ansiClear      = "\x1b[2J"
+	ansiClearLine  = "\x1b[K"
+	ansiReset      = "\x1b[0m"
+	AnsiHideCursor = "\x1b[?25l"
+	AnsiShowCursor = "\x1b[?25h"
+)
+
+// Player steps through frames and draws them in a terminal.
+type Player struct {
+	Frames []Frame
+	// FPS is how many frames are shown per second while playing.
+	FPS int
+	// Index is the frame being shown.
+	Index int
+	// Paused stops Tick from advancing.
+	Paused bool
+
+	cleared bool
+}
+
+// NewPlayer returns a player at the first frame.
+func NewPlayer(frames []Frame, fps int) *Player {
+	return &Player{Frames: frames, FPS: max(fps, 1)}
+}
+
+// ParseKeys splits terminal input into keys. Arrow keys and the home and
+// end keys are sent as escape sequences, the other keys are themselves.
+func ParseKeys(b []byte) []string {
+	var keys []string
+	for i := 0; i < len(b); i++ {
+		if b[i] == 0x1b && i+2 < len(b) && b[i+1] == '[' {
+			switch b[i+2] {
+			case 'C':
+				keys = append(keys, KeyNext)
+			case 'D':
+				keys = append(keys, KeyPrev)
+			case 'H':
+				keys = append(keys, KeyStart)
+			case 'F':
+				keys = append(keys, KeyEnd)
+			}
+			i += 2
+			continue
+		}
+		switch b[i] {
+		case ' ':
+			keys = append(keys, KeyPlay)
+		case 'l', '.':
+			keys = append(keys, KeyNext)
+		case 'h', ',':
+			keys = append(keys, KeyPrev)
+		case 'g':
+			keys = append(keys, KeyStart)
+		case 'G':
+			keys = append(keys, KeyEnd)
+		case 'q', 0x03:
+			keys = append(keys, KeyQuit)
+		case '=':
+			keys = append(keys, KeyFast)
+		default:
+			keys = append(keys, string(b[i]))
+		}
+	}
+	return keys
+}
+
+// Handle applies a key and reports whether the player should quit. Stepping
+// pauses, and the digits 0 to 9 seek to that tenth of the frames.
+func (p *Player) Handle(key string) bool {
+	switch key {
+	case KeyQuit:
+		return true
+	case KeyPlay:
+		p.Paused = !p.Paused
+		if !p.Paused && p.Index == len(p.Frames)-1 {
+			p.Index = 0
+		}
+	case KeyNext:
+		p.Paused = true
+		p.Seek(p.Index + 1)
+	case KeyPrev:
+		p.Paused = true
+		p.Seek(p.Index - 1)
+	case KeyStart:
+		p.Seek(0)
+	case KeyEnd:
+		p.Seek(len(p.Frames) - 1)
+	case KeyFast:
+		p.FPS = min(p.FPS*2, 240)
+	case KeySlow:
+		p.FPS = max(p.FPS/2, 1)
+	default:
+		if len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+			p.Seek(int(key[0]-'0') * len(p.Frames) / 10)
+		}
+	}
+	return false
+}
+
+// Seek moves to the frame, clamped to the recorded frames.
+func (p *Player) Seek(i int) {
+	p.Index = max(0, min(i, len(p.Frames)-1))
+}
+
+// Tick advances a playing player by one frame, pausing on the last one. It
+// reports whether the frame changed.
+func (p *Player) Tick() bool {
+	if p.Paused {
+		return false
+	}
+	if p.Index >= len(p.Frames)-1 {
+		p.Paused = true
+		return false
+	}
+	p.Index++
+	return true
+}
+
+// Draw writes the current frame and a status line. Each cell is drawn as
+// two spaces with its color as the background, so cells are roughly square.
+func (p *Player) Draw(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if !p.cleared {
+		bw.WriteString(ansiClear)
+		p.cleared = true
+	}
+	bw.WriteString(ansiHome)
+	if len(p.Frames) > 0 {
+		f := p.Frames[p.Index]
+		for _, row := range f.Cells {
+			last := -1
+			for _, idx := range row {
+				if int(idx) != last && int(idx) < len(f.Palette) {
+					r, g, b, _ := f.Palette[idx].RGBA()
+					fmt.Fprintf(bw, "\x1b[48;2;%d;%d;%dm", r>>8, g>>8, b>>8)
+					last = int(idx)
+				}
+				bw.WriteString("  ")
+			}
+			bw.WriteString(ansiReset + ansiClearLine + "\r\n")
+		}
+	}
+	state := "playing"
+	if p.Paused {
+		state = "paused"
+	}
+	fmt.Fprintf(bw, "frame %d/%d  %d fps  %s  [space] play/pause [<- ->] step [0-9] seek [+-] speed [q] quit%s",
+		p.Index+1, len(p.Frames), p.FPS, state, ansiClearLine)
+	return bw.Flush()
+}
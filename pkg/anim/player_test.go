@@ -0,0 +1,45 @@
+package anim_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/anim"
+)
+
+func TestParseKeys(t *testing.T) {
+	got := anim.ParseKeys([]byte("  \x1b[C\x1b[Dq5"))
+	want := []string{anim.KeyPlay, anim.KeyPlay, anim.KeyNext, anim.KeyPrev, anim.KeyQuit, "5"}
+	if !slices.Equal(got, want) {
+		t.Errorf("want: %v got: %v", want, got)
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	frames := make([]anim.Frame, 20)
+	p := anim.NewPlayer(frames, 10)
+
+	for i := 0; i < 25; i++ {
+		p.Tick()
+	}
+	if p.Index != 19 || !p.Paused {
+		t.Errorf("should pause on the last frame, got: %v paused %v", p.Index, p.Paused)
+	}
+
+	p.Handle(anim.KeyPlay)
+	if p.Index != 0 || p.Paused {
+		t.Errorf("play at the end should restart, got: %v paused %v", p.Index, p.Paused)
+	}
+	p.Handle("5")
+	p.Handle(anim.KeyNext)
+	if p.Index != 11 || !p.Paused {
+		t.Errorf("seek and step, want: 11 paused got: %v paused %v", p.Index, p.Paused)
+	}
+	p.Handle(anim.KeySlow)
+	if p.FPS != 5 {
+		t.Errorf("fps, want: 5 got: %v", p.FPS)
+	}
+	if !p.Handle(anim.KeyQuit) {
+		t.Errorf("q should quit")
+	}
+}
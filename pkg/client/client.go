@@ -0,0 +1,115 @@
+// Package client fetches pages from the Advent of Code website.
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	// DefaultBaseURL is the Advent of Code website.
+	DefaultBaseURL = "https://adventofcode.com"
+	// UserAgent identifies this tool, as requested by the site owner.
+	UserAgent = "github.com/mikehelmick/adventofcode/cmd/aoc"
+
+	// part2Marker is present on a puzzle page once part 2 is unlocked.
+	part2Marker = `id="part2"`
+)
+
+// Doer sends HTTP requests. *http.Client satisfies this, and tests can
+// supply a client pointed at an httptest.Server.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client fetches pages from the site, authenticated with a session cookie
+// when one is set.
+type Client struct {
+	BaseURL string
+	Session string
+	HTTP    Doer
+}
+
+// New returns a client for the real site. Redirects aren't followed, the
+// site redirects to its login page when the session has expired and that
+// should be an error rather than a page to parse.
+func New(session string) *Client {
+	return &Client{
+		BaseURL: DefaultBaseURL,
+		Session: session,
+		HTTP: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// PuzzleURL returns the URL of the puzzle page for the year and day.
+func (c *Client) PuzzleURL(year, day int) (*url.URL, error) {
+	return url.Parse(fmt.Sprintf("%s/%d/day/%d", strings.TrimSuffix(c.BaseURL, "/"), year, day))
+}
+
+// Puzzle fetches the puzzle page for the year and day.
+func (c *Client) Puzzle(ctx context.Context, year, day int) ([]byte, error) {
+	u, err := c.PuzzleURL(year, day)
+	if err != nil {
+		return nil, err
+	}
+	return c.get(ctx, u.String())
+}
+
+// CachedPuzzle returns the puzzle page stored at path, fetching it first if
+// it is missing, if part 2 was still locked when it was cached, or if
+// refresh is set.
+func (c *Client) CachedPuzzle(ctx context.Context, year, day int, path string, refresh bool) ([]byte, error) {
+	if !refresh {
+		if data, err := os.ReadFile(path); err == nil && bytes.Contains(data, []byte(part2Marker)) {
+			return data, nil
+		}
+	}
+
+	data, err := c.Puzzle(ctx, year, day)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("writing cache: %w", err)
+	}
+	return data, nil
+}
+
+func (c *Client) get(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	if c.Session != "" {
+		req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		return nil, fmt.Errorf("GET %s: %s to %s, has the session expired?", u, resp.Status, resp.Header.Get("Location"))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	return body, nil
+}
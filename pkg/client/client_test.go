@@ -0,0 +1,79 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/client"
+)
+
+const (
+	lockedPage   = `<main><article class="day-desc"><h2>--- Day 3: Test ---</h2></article></main>`
+	unlockedPage = `<main><article class="day-desc"><h2>--- Day 3: Test ---</h2></article><article class="day-desc"><h2 id="part2">--- Part Two ---</h2></article></main>`
+)
+
+func TestCachedPuzzle(t *testing.T) {
+	requests := 0
+	page := lockedPage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/2023/day/3" {
+			t.Errorf("wrong path: %v", r.URL.Path)
+		}
+		if c, err := r.Cookie("session"); err != nil || c.Value != "secret" {
+			t.Errorf("missing session cookie")
+		}
+		w.Write([]byte(page))
+	}))
+	defer srv.Close()
+
+	c := &client.Client{BaseURL: srv.URL, Session: "secret", HTTP: srv.Client()}
+	path := filepath.Join(t.TempDir(), "puzzle.html")
+	ctx := context.Background()
+
+	if _, err := c.CachedPuzzle(ctx, 2023, 3, path, false); err != nil {
+		t.Fatal(err)
+	}
+	// Part 2 is locked, so the page is fetched again.
+	page = unlockedPage
+	if _, err := c.CachedPuzzle(ctx, 2023, 3, path, false); err != nil {
+		t.Fatal(err)
+	}
+	// Now it is served from the cache.
+	data, err := c.CachedPuzzle(ctx, 2023, 3, path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("wrong number of requests, want: 2 got: %v", requests)
+	}
+	if string(data) != unlockedPage {
+		t.Errorf("wrong page content: %q", data)
+	}
+	if onDisk, _ := os.ReadFile(path); string(onDisk) != unlockedPage {
+		t.Errorf("page was not cached: %q", onDisk)
+	}
+
+	if _, err := c.CachedPuzzle(ctx, 2023, 3, path, true); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("refresh should fetch, want: 3 got: %v", requests)
+	}
+}
+
+func TestPuzzleError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	c := &client.Client{BaseURL: srv.URL, HTTP: srv.Client()}
+	_, err := c.Puzzle(context.Background(), 2023, 26)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("want 404 error, got: %v", err)
+	}
+}
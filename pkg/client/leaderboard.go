@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LeaderboardInterval is the minimum time between requests for a private
+// leaderboard. The site asks that they are not fetched more often than this.
+const LeaderboardInterval = 15 * time.Minute
+
+// LeaderboardURL returns the URL of the JSON for a private leaderboard.
+func (c *Client) LeaderboardURL(year int, id string) string {
+	return fmt.Sprintf("%s/%d/leaderboard/private/view/%s.json", strings.TrimSuffix(c.BaseURL, "/"), year, id)
+}
+
+// Leaderboard returns the private leaderboard JSON, cached at path. The
+// cached copy is used until it is LeaderboardInterval old, which also
+// keeps repeated runs from hitting the site too often.
+func (c *Client) Leaderboard(ctx context.Context, year int, id string, path string) ([]byte, error) {
+	if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < LeaderboardInterval {
+		return os.ReadFile(path)
+	}
+	if c.Session == "" {
+		return nil, fmt.Errorf("private leaderboards require a session")
+	}
+
+	data, err := c.get(ctx, c.LeaderboardURL(year, id))
+	if err != nil {
+		return nil, err
+	}
+	// Only cache a real leaderboard, an error page would be served from
+	// the cache until it expired.
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("leaderboard %s for %d is not JSON, has the session expired?", id, year)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("writing cache: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("writing cache: %w", err)
+	}
+	return data, nil
+}
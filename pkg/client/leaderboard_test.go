@@ -0,0 +1,93 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/client"
+)
+
+func TestLeaderboardCache(t *testing.T) {
+	fixture, err := os.ReadFile(filepath.Join("..", "leaderboard", "testdata", "2023.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/2023/leaderboard/private/view/100.json" {
+			t.Errorf("wrong path: %v", r.URL.Path)
+		}
+		w.Write(fixture)
+	}))
+	defer srv.Close()
+
+	c := &client.Client{BaseURL: srv.URL, Session: "secret", HTTP: srv.Client()}
+	path := filepath.Join(t.TempDir(), "cache", "2023-100.json")
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		data, err := c.Leaderboard(ctx, 2023, "100", path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != string(fixture) {
+			t.Fatalf("wrong leaderboard data")
+		}
+	}
+	if requests != 1 {
+		t.Errorf("want a single request while cached, got: %v", requests)
+	}
+
+	old := time.Now().Add(-client.LeaderboardInterval)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Leaderboard(ctx, 2023, "100", path); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("want a refresh after the interval, got: %v", requests)
+	}
+}
+
+func TestLeaderboardNotJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>log in</html>"))
+	}))
+	defer srv.Close()
+
+	c := &client.Client{BaseURL: srv.URL, Session: "expired", HTTP: srv.Client()}
+	path := filepath.Join(t.TempDir(), "2023-100.json")
+	if _, err := c.Leaderboard(context.Background(), 2023, "100", path); err == nil {
+		t.Errorf("want an error for a page that isn't JSON")
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Errorf("the page was cached")
+	}
+}
+
+func TestExpiredSessionRedirect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			w.Write([]byte("<html>log in</html>"))
+			return
+		}
+		http.Redirect(w, r, "/login", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	c := client.New("expired")
+	c.BaseURL = srv.URL
+	_, err := c.Leaderboard(context.Background(), 2023, "100", filepath.Join(t.TempDir(), "2023-100.json"))
+	if err == nil || !strings.Contains(err.Error(), "302") {
+		t.Errorf("want a redirect error, got: %v", err)
+	}
+}
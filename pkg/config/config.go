@@ -0,0 +1,346 @@
+// Package config loads user settings shared by the launchers and the aoc
+// command.
+//
+// Settings are resolved with the precedence flags > environment > config
+// file > defaults. Load applies the file and the environment, and
+// RegisterFlags uses the result as the flag defaults so anything set on the
+// command line wins.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"go.uber.org/zap"
+)
+
+// Environment variables that override the config file.
+const (
+	EnvConfig      = "AOC_CONFIG"
+	EnvSession     = "AOC_SESSION"
+	EnvSessionFile = "AOC_SESSION_FILE"
+	EnvInputDir    = "AOC_INPUT_DIR"
+	EnvYear        = "AOC_YEAR"
+	EnvFormat      = "AOC_FORMAT"
+	EnvTimeout     = "AOC_TIMEOUT"
+	EnvLogLevel    = logging.EnvLevel
+	EnvLogFormat   = logging.EnvFormat
+	EnvLogFile     = logging.EnvFile
+	EnvLogLevels   = logging.EnvLevels
+	EnvLogSample   = logging.EnvSample
+)
+
+// Config is the set of user settings.
+type Config struct {
+	// SessionFile contains the session cookie for the website.
+	SessionFile string `json:"session_file,omitempty"`
+	// InputDir is the directory containing the aocYYYY/dayXX directories
+	// with inputs and examples. When empty, the repository root is used.
+	InputDir string `json:"input_dir,omitempty"`
+	// DefaultYear is used when a command is not given a year.
+	DefaultYear int `json:"default_year,omitempty"`
+	// OutputFormat is text, json or csv for commands that support it.
+	OutputFormat string `json:"output_format,omitempty"`
+	// Timeout limits how long a solver or request may run, zero is no limit.
+	Timeout Duration `json:"timeout,omitempty"`
+	// LogLevel is DEBUG, INFO, WARNING or ERROR.
+	LogLevel string `json:"log_level,omitempty"`
+	// LogFormat is console or json.
+	LogFormat string `json:"log_format,omitempty"`
+	// LogFile is a file that logs are written to as well as stdout.
+	LogFile string `json:"log_file,omitempty"`
+	// LogLevels overrides LogLevel for named loggers.
+	LogLevels map[string]string `json:"log_levels,omitempty"`
+	// LogSample limits how many times a message is logged per second, zero
+	// is no limit.
+	LogSample int `json:"log_sample,omitempty"`
+
+	// session is the cookie from $AOC_SESSION, it sits at the environment
+	// layer so a -session-file flag overrides it.
+	session string
+	// sessionFileFlag is set when -session-file was given.
+	sessionFileFlag bool
+}
+
+// Duration is a time.Duration written as a string like "30s" in JSON.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Default returns the settings used when nothing else is configured.
+func Default() *Config {
+	c := &Config{
+		DefaultYear:  CurrentYear(time.Now()),
+		OutputFormat: "text",
+		LogLevel:     "INFO",
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		c.SessionFile = filepath.Join(dir, "aoc", "session")
+	}
+	return c
+}
+
+// CurrentYear is the most recent event that has started.
+func CurrentYear(now time.Time) int {
+	if now.Month() < time.December {
+		return now.Year() - 1
+	}
+	return now.Year()
+}
+
+// Path returns the location of the config file, $AOC_CONFIG or
+// $XDG_CONFIG_HOME/aoc/config.json.
+func Path() string {
+	if p := os.Getenv(EnvConfig); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "aoc", "config.json")
+}
+
+// Load returns the defaults overridden by the config file and then by the
+// environment.
+func Load() (*Config, error) {
+	c := Default()
+	if p := Path(); p != "" {
+		if err := c.LoadFile(p); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.ApplyEnv(os.Getenv); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LoadFile overrides settings with those present in the file. A missing
+// file is not an error.
+func (c *Config) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	var file Config
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	c.merge(&file)
+	return nil
+}
+
+// ApplyEnv overrides settings with those set in the environment.
+func (c *Config) ApplyEnv(getenv func(string) string) error {
+	env := Config{
+		SessionFile:  getenv(EnvSessionFile),
+		InputDir:     getenv(EnvInputDir),
+		OutputFormat: getenv(EnvFormat),
+		LogLevel:     getenv(EnvLogLevel),
+		LogFormat:    getenv(EnvLogFormat),
+		LogFile:      getenv(EnvLogFile),
+	}
+	levels, err := logging.ParseLevels(getenv(EnvLogLevels))
+	if err != nil {
+		return fmt.Errorf("%s: %w", EnvLogLevels, err)
+	}
+	env.LogLevels = levels
+	if v := getenv(EnvSession); v != "" {
+		c.session = v
+	}
+	if v := getenv(EnvLogSample); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", EnvLogSample, err)
+		}
+		env.LogSample = n
+	}
+	if v := getenv(EnvYear); v != "" {
+		year, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", EnvYear, err)
+		}
+		env.DefaultYear = year
+	}
+	if v := getenv(EnvTimeout); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", EnvTimeout, err)
+		}
+		env.Timeout = Duration(d)
+	}
+	c.merge(&env)
+	return nil
+}
+
+// merge copies the settings that are set in o.
+func (c *Config) merge(o *Config) {
+	if o.SessionFile != "" {
+		c.SessionFile = o.SessionFile
+	}
+	if o.InputDir != "" {
+		c.InputDir = o.InputDir
+	}
+	if o.DefaultYear != 0 {
+		c.DefaultYear = o.DefaultYear
+	}
+	if o.OutputFormat != "" {
+		c.OutputFormat = o.OutputFormat
+	}
+	if o.Timeout != 0 {
+		c.Timeout = o.Timeout
+	}
+	if o.LogLevel != "" {
+		c.LogLevel = o.LogLevel
+	}
+	if o.LogFormat != "" {
+		c.LogFormat = o.LogFormat
+	}
+	if o.LogFile != "" {
+		c.LogFile = o.LogFile
+	}
+	if len(o.LogLevels) > 0 {
+		c.LogLevels = o.LogLevels
+	}
+	if o.LogSample != 0 {
+		c.LogSample = o.LogSample
+	}
+}
+
+// RegisterFlags adds flags for the shared settings, defaulting to the
+// current values so that parsing the flags applies the final override.
+// The year is left to each command since the launchers are fixed to one.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.Func("session-file", fmt.Sprintf("-session-file path to a file containing the session cookie (default %q)", c.SessionFile), func(s string) error {
+		c.SessionFile = s
+		c.sessionFileFlag = true
+		return nil
+	})
+	fs.StringVar(&c.InputDir, "input-dir", c.InputDir, "-input-dir path containing the aocYYYY/dayXX input directories")
+	fs.StringVar(&c.OutputFormat, "format", c.OutputFormat, "-format text|json|csv for commands with structured output")
+	fs.DurationVar((*time.Duration)(&c.Timeout), "timeout", time.Duration(c.Timeout), "-timeout 30s to limit how long a run may take")
+	fs.StringVar(&c.LogLevel, "log-level", c.LogLevel, "-log-level DEBUG|INFO|WARNING|ERROR")
+	fs.StringVar(&c.LogFormat, "log-format", c.LogFormat, "-log-format console|json")
+	fs.StringVar(&c.LogFile, "log-file", c.LogFile, "-log-file path to also write logs to")
+	fs.Func("log-levels", "-log-levels name=LEVEL,... to set the level of named loggers", func(s string) error {
+		levels, err := logging.ParseLevels(s)
+		if err != nil {
+			return err
+		}
+		c.LogLevels = levels
+		return nil
+	})
+	fs.IntVar(&c.LogSample, "log-sample", c.LogSample, "-log-sample N to only log the first N of each message per second, then every Nth")
+}
+
+// Session returns the session cookie. Like the other settings a
+// -session-file flag wins over $AOC_SESSION, which wins over a session file
+// from $AOC_SESSION_FILE, the config file or the default. It is empty if
+// none are set.
+func (c *Config) Session() (string, error) {
+	if c.session != "" && !c.sessionFileFlag {
+		return c.session, nil
+	}
+	if c.SessionFile == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(c.SessionFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// DayDir returns the directory holding the inputs for a day.
+func (c *Config) DayDir(year, day int) string {
+	root := c.InputDir
+	if root == "" {
+		root = RepoRoot()
+	}
+	return filepath.Join(root, fmt.Sprintf("aoc%d", year), fmt.Sprintf("day%02d", day))
+}
+
+// LogOptions are the logging settings.
+func (c *Config) LogOptions() logging.Options {
+	return logging.Options{
+		Level:  c.LogLevel,
+		Format: c.LogFormat,
+		File:   c.LogFile,
+		Levels: c.LogLevels,
+		Sample: c.LogSample,
+	}
+}
+
+// Logger returns a logger with the configured logging settings and the
+// function that flushes it and closes the log file.
+func (c *Config) Logger() (*zap.SugaredLogger, func(), error) {
+	return logging.New(c.LogOptions())
+}
+
+// ExportLogEnv sets the logging environment variables so that code reading
+// them, like logging.IsDebug, matches the configured settings.
+func (c *Config) ExportLogEnv() {
+	env := map[string]string{
+		EnvLogLevel:  c.LogLevel,
+		EnvLogFormat: c.LogFormat,
+		EnvLogFile:   c.LogFile,
+		EnvLogLevels: logging.FormatLevels(c.LogLevels),
+	}
+	if c.LogSample != 0 {
+		env[EnvLogSample] = strconv.Itoa(c.LogSample)
+	}
+	for k, v := range env {
+		if v != "" {
+			os.Setenv(k, v)
+		}
+	}
+}
+
+// RepoRoot finds the repository root by walking up from the working
+// directory to the nearest go.mod. It falls back to the working directory.
+func RepoRoot() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	for dir := wd; ; {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return wd
+		}
+		dir = parent
+	}
+}
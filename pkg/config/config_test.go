@@ -0,0 +1,125 @@
+package config_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/config"
+)
+
+func TestPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	file := `{"input_dir": "/file/inputs", "default_year": 2022, "output_format": "csv", "timeout": "10s", "log_level": "WARNING"}`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := config.Default()
+	if err := c.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	env := map[string]string{
+		config.EnvYear:      "2023",
+		config.EnvLogLevel:  "DEBUG",
+		config.EnvLogLevels: "dfs=DEBUG",
+	}
+	if err := c.ApplyEnv(func(k string) string { return env[k] }); err != nil {
+		t.Fatal(err)
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	c.RegisterFlags(fs)
+	if err := fs.Parse([]string{"-log-level", "ERROR", "-log-sample", "10"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.InputDir != "/file/inputs" {
+		t.Errorf("input dir from file, want: /file/inputs got: %v", c.InputDir)
+	}
+	if c.OutputFormat != "csv" {
+		t.Errorf("format from file, want: csv got: %v", c.OutputFormat)
+	}
+	if time.Duration(c.Timeout) != 10*time.Second {
+		t.Errorf("timeout from file, want: 10s got: %v", time.Duration(c.Timeout))
+	}
+	if c.DefaultYear != 2023 {
+		t.Errorf("year from env, want: 2023 got: %v", c.DefaultYear)
+	}
+	if c.LogLevel != "ERROR" {
+		t.Errorf("log level from flag, want: ERROR got: %v", c.LogLevel)
+	}
+	opts := c.LogOptions()
+	if opts.Levels["dfs"] != "DEBUG" || opts.Sample != 10 {
+		t.Errorf("log options from env and flag, want: dfs=DEBUG and 10 got: %v and %v", opts.Levels, opts.Sample)
+	}
+	if want := filepath.Join("/file/inputs", "aoc2023", "day05"); c.DayDir(2023, 5) != want {
+		t.Errorf("wrong day dir, want: %v got: %v", want, c.DayDir(2023, 5))
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	c := config.Default()
+	if err := c.LoadFile(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Fatalf("missing file should not be an error: %v", err)
+	}
+	if c.OutputFormat != "text" || c.LogLevel != "INFO" || c.Timeout != 0 {
+		t.Errorf("wrong defaults: %+v", c)
+	}
+	if got := config.CurrentYear(time.Date(2024, time.November, 30, 0, 0, 0, 0, time.UTC)); got != 2023 {
+		t.Errorf("before december, want: 2023 got: %v", got)
+	}
+	if got := config.CurrentYear(time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)); got != 2024 {
+		t.Errorf("in december, want: 2024 got: %v", got)
+	}
+
+	if err := c.ApplyEnv(func(k string) string {
+		if k == config.EnvYear {
+			return "twenty"
+		}
+		return ""
+	}); err == nil {
+		t.Errorf("expected error for invalid year")
+	}
+}
+
+func TestSessionPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	fromFile := filepath.Join(dir, "config-session")
+	fromFlag := filepath.Join(dir, "flag-session")
+	for path, session := range map[string]string{fromFile: "file-cookie", fromFlag: "flag-cookie"} {
+		if err := os.WriteFile(path, []byte(session+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	env := map[string]string{config.EnvSession: "env-cookie"}
+
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "env over config file", want: "env-cookie"},
+		{name: "flag over env", args: []string{"-session-file", fromFlag}, want: "flag-cookie"},
+	}
+	for _, tc := range cases {
+		c := config.Default()
+		c.SessionFile = fromFile
+		if err := c.ApplyEnv(func(k string) string { return env[k] }); err != nil {
+			t.Fatal(err)
+		}
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		c.RegisterFlags(fs)
+		if err := fs.Parse(tc.args); err != nil {
+			t.Fatal(err)
+		}
+		got, err := c.Session()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("%s, want: %v got: %v", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package counter counts how many times each value is seen, a multiset.
+package counter
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Counter maps each key to how many times it was counted. Keys whose count
+// drops to zero are removed, so len is the number of distinct keys. The
+// zero value can be read but needs New or make before adding.
+type Counter[K cmp.Ordered] map[K]int
+
+// Entry is a key and its count.
+type Entry[K cmp.Ordered] struct {
+	Key   K
+	Count int
+}
+
+// New returns a counter with each of the keys counted.
+func New[K cmp.Ordered](keys ...K) Counter[K] {
+	c := make(Counter[K], len(keys))
+	for _, k := range keys {
+		c[k]++
+	}
+	return c
+}
+
+// Of counts the values of a sequence.
+func Of[K cmp.Ordered](seq iter.Seq[K]) Counter[K] {
+	c := make(Counter[K])
+	for k := range seq {
+		c[k]++
+	}
+	return c
+}
+
+// Add counts the key once more.
+func (c Counter[K]) Add(k K) {
+	c.AddN(k, 1)
+}
+
+// AddN adds n to the key's count, n may be negative.
+func (c Counter[K]) AddN(k K, n int) {
+	if v := c[k] + n; v != 0 {
+		c[k] = v
+	} else {
+		delete(c, k)
+	}
+}
+
+// Count is how many times the key was counted.
+func (c Counter[K]) Count(k K) int {
+	return c[k]
+}
+
+// Total is the sum of the counts.
+func (c Counter[K]) Total() int {
+	total := 0
+	for _, v := range c {
+		total += v
+	}
+	return total
+}
+
+// Clone returns a copy of the counter.
+func (c Counter[K]) Clone() Counter[K] {
+	out := make(Counter[K], len(c))
+	maps.Copy(out, c)
+	return out
+}
+
+// Equal reports whether the counters have the same counts.
+func (c Counter[K]) Equal(o Counter[K]) bool {
+	return maps.Equal(c, o)
+}
+
+// Keys iterates over the keys in order.
+func (c Counter[K]) Keys() iter.Seq[K] {
+	return slices.Values(slices.Sorted(maps.Keys(c)))
+}
+
+// All iterates over the keys in order with their counts.
+func (c Counter[K]) All() iter.Seq2[K, int] {
+	return func(yield func(K, int) bool) {
+		for k := range c.Keys() {
+			if !yield(k, c[k]) {
+				return
+			}
+		}
+	}
+}
+
+// MostCommon returns the n keys with the highest counts, highest first and
+// ties in key order. A negative n returns them all.
+func (c Counter[K]) MostCommon(n int) []Entry[K] {
+	entries := make([]Entry[K], 0, len(c))
+	for k, v := range c {
+		entries = append(entries, Entry[K]{Key: k, Count: v})
+	}
+	slices.SortFunc(entries, func(a, b Entry[K]) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	if n >= 0 && n < len(entries) {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// Plus returns the counts of both counters added together.
+func (c Counter[K]) Plus(o Counter[K]) Counter[K] {
+	out := c.Clone()
+	for k, v := range o {
+		out.AddN(k, v)
+	}
+	return out
+}
+
+// Minus returns the counts of c less those of o, keeping only keys that
+// are left with a positive count like removing items from a multiset.
+func (c Counter[K]) Minus(o Counter[K]) Counter[K] {
+	out := make(Counter[K], len(c))
+	for k, v := range c {
+		if left := v - o[k]; left > 0 {
+			out[k] = left
+		}
+	}
+	return out
+}
+
+// Intersect returns the smaller count of each key in both counters.
+func (c Counter[K]) Intersect(o Counter[K]) Counter[K] {
+	out := make(Counter[K])
+	for k, v := range c {
+		if m := min(v, o[k]); m > 0 {
+			out[k] = m
+		}
+	}
+	return out
+}
+
+// Transform replaces every key with the keys f returns for it, each
+// carrying the old key's count. Keys that turn into the same new key have
+// their counts merged, so a million equal values cost one call to f.
+func Transform[K, J cmp.Ordered](c Counter[K], f func(K) []J) Counter[J] {
+	out := make(Counter[J], len(c))
+	for k, v := range c {
+		for _, j := range f(k) {
+			out.AddN(j, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package counter_test
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/counter"
+)
+
+func TestAddN(t *testing.T) {
+	c := counter.New("a", "b", "a")
+	c.Add("c")
+	c.AddN("b", -1)
+	c.AddN("a", 3)
+
+	want := counter.Counter[string]{"a": 5, "c": 1}
+	if !c.Equal(want) {
+		t.Errorf("counts, want: %v got: %v", want, c)
+	}
+	if got := c.Total(); got != 6 {
+		t.Errorf("Total, want: 6 got: %v", got)
+	}
+	if got := c.Count("b"); got != 0 {
+		t.Errorf("Count(b), want: 0 got: %v", got)
+	}
+}
+
+func TestMostCommon(t *testing.T) {
+	c := counter.New(strings.Split("abracadabra", "")...)
+
+	cases := []struct {
+		n    int
+		want []counter.Entry[string]
+	}{
+		{n: 0, want: []counter.Entry[string]{}},
+		{n: 1, want: []counter.Entry[string]{{Key: "a", Count: 5}}},
+		{n: 3, want: []counter.Entry[string]{{Key: "a", Count: 5}, {Key: "b", Count: 2}, {Key: "r", Count: 2}}},
+		{n: -1, want: []counter.Entry[string]{{Key: "a", Count: 5}, {Key: "b", Count: 2}, {Key: "r", Count: 2}, {Key: "c", Count: 1}, {Key: "d", Count: 1}}},
+	}
+	for _, tc := range cases {
+		if got := c.MostCommon(tc.n); !slices.Equal(got, tc.want) {
+			t.Errorf("MostCommon(%d), want: %v got: %v", tc.n, tc.want, got)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := counter.Counter[int]{1: 3, 2: 1}
+	b := counter.Counter[int]{1: 1, 2: 2, 3: 1}
+
+	if got, want := a.Plus(b), (counter.Counter[int]{1: 4, 2: 3, 3: 1}); !got.Equal(want) {
+		t.Errorf("Plus, want: %v got: %v", want, got)
+	}
+	if got, want := a.Minus(b), (counter.Counter[int]{1: 2}); !got.Equal(want) {
+		t.Errorf("Minus, want: %v got: %v", want, got)
+	}
+	if got, want := a.Intersect(b), (counter.Counter[int]{1: 1, 2: 1}); !got.Equal(want) {
+		t.Errorf("Intersect, want: %v got: %v", want, got)
+	}
+	if got, want := a, (counter.Counter[int]{1: 3, 2: 1}); !got.Equal(want) {
+		t.Errorf("receiver changed, want: %v got: %v", want, got)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	c := counter.New(1, 2, 2, 3)
+	got := counter.Transform(c, func(v int) []int {
+		if v == 2 {
+			return []int{4, 5}
+		}
+		return []int{v % 2}
+	})
+
+	want := counter.Counter[int]{1: 2, 4: 2, 5: 2}
+	if !got.Equal(want) {
+		t.Errorf("Transform, want: %v got: %v", want, got)
+	}
+}
+
+func TestAll(t *testing.T) {
+	c := counter.New("pear", "apple", "fig", "apple")
+
+	if got, want := slices.Collect(c.Keys()), []string{"apple", "fig", "pear"}; !slices.Equal(got, want) {
+		t.Errorf("Keys, want: %v got: %v", want, got)
+	}
+	var counts []int
+	for _, n := range c.All() {
+		counts = append(counts, n)
+	}
+	if want := []int{2, 1, 1}; !slices.Equal(counts, want) {
+		t.Errorf("All counts, want: %v got: %v", want, counts)
+	}
+	if got := maps.Collect(c.All()); !c.Equal(got) {
+		t.Errorf("All, want: %v got: %v", c, got)
+	}
+}
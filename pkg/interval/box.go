@@ -0,0 +1,249 @@
+package interval
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// Box is a hyperrectangle, an interval in each of its dimensions. The
+// dimensions are keyed by K, so they can be named like "x" or numbered,
+// and keep the order they were added in. A box is unbounded in dimensions
+// it doesn't have. Like Set, the methods return new boxes rather than
+// changing their receiver.
+type Box[K comparable, T Integer] struct {
+	keys []K
+	ivs  []Interval[T]
+}
+
+// NewBox returns a box with no dimensions, add them with With.
+func NewBox[K comparable, T Integer]() Box[K, T] {
+	return Box[K, T]{}
+}
+
+// Cube returns a box with the intervals as dimensions 0, 1, 2 and so on.
+func Cube[T Integer](ivs ...Interval[T]) Box[int, T] {
+	b := Box[int, T]{keys: make([]int, len(ivs)), ivs: slices.Clone(ivs)}
+	for i := range ivs {
+		b.keys[i] = i
+	}
+	return b
+}
+
+func (b Box[K, T]) index(k K) int {
+	return slices.Index(b.keys, k)
+}
+
+// With returns the box with the dimension set to iv, adding it if it is
+// new.
+func (b Box[K, T]) With(k K, iv Interval[T]) Box[K, T] {
+	out := Box[K, T]{keys: slices.Clone(b.keys), ivs: slices.Clone(b.ivs)}
+	if i := out.index(k); i >= 0 {
+		out.ivs[i] = iv
+		return out
+	}
+	out.keys = append(out.keys, k)
+	out.ivs = append(out.ivs, iv)
+	return out
+}
+
+// Dim returns the interval of a dimension, false if the box doesn't have
+// it.
+func (b Box[K, T]) Dim(k K) (Interval[T], bool) {
+	if i := b.index(k); i >= 0 {
+		return b.ivs[i], true
+	}
+	return Interval[T]{}, false
+}
+
+// Dims iterates over the dimensions in order.
+func (b Box[K, T]) Dims() iter.Seq2[K, Interval[T]] {
+	return func(yield func(K, Interval[T]) bool) {
+		for i, k := range b.keys {
+			if !yield(k, b.ivs[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Empty reports whether the box holds no points.
+func (b Box[K, T]) Empty() bool {
+	return slices.ContainsFunc(b.ivs, Interval[T].Empty)
+}
+
+// Volume is the number of points in the box.
+func (b Box[K, T]) Volume() *big.Int {
+	v := big.NewInt(1)
+	for _, iv := range b.ivs {
+		v.Mul(v, new(big.Int).SetUint64(uint64(iv.Len())))
+	}
+	return v
+}
+
+// Contains reports whether the point, keyed by dimension, is in the box.
+// Dimensions missing from the point are not checked.
+func (b Box[K, T]) Contains(point map[K]T) bool {
+	for i, k := range b.keys {
+		if v, ok := point[k]; ok && !b.ivs[i].Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Points iterates over every point in the box, with the values in
+// dimension order. It visits Volume points, so is only for small boxes.
+func (b Box[K, T]) Points() iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if b.Empty() {
+			return
+		}
+		point := make([]T, len(b.ivs))
+		var walk func(d int) bool
+		walk = func(d int) bool {
+			if d == len(b.ivs) {
+				return yield(slices.Clone(point))
+			}
+			for v := b.ivs[d].Start; v < b.ivs[d].End; v++ {
+				point[d] = v
+				if !walk(d + 1) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(0)
+	}
+}
+
+// Intersect returns the points in both boxes. A dimension only one of the
+// boxes has is not limited by the other.
+func (b Box[K, T]) Intersect(o Box[K, T]) Box[K, T] {
+	out := b
+	for i, k := range o.keys {
+		iv, ok := out.Dim(k)
+		if !ok {
+			out = out.With(k, o.ivs[i])
+			continue
+		}
+		out = out.With(k, iv.Intersect(o.ivs[i]))
+	}
+	return out
+}
+
+// Subtract returns disjoint boxes holding the points of b that are not in
+// o. Every dimension of o must also be one of b.
+func (b Box[K, T]) Subtract(o Box[K, T]) []Box[K, T] {
+	if b.Intersect(o).Empty() {
+		if b.Empty() {
+			return nil
+		}
+		return []Box[K, T]{b}
+	}
+
+	var out []Box[K, T]
+	rest := b
+	for i, k := range o.keys {
+		iv, ok := rest.Dim(k)
+		if !ok {
+			continue
+		}
+		// Peel off the slabs of rest below and above o in this dimension.
+		below, mid := iv.SplitAt(o.ivs[i].Start)
+		mid, above := mid.SplitAt(o.ivs[i].End)
+		for _, slab := range []Interval[T]{below, above} {
+			if !slab.Empty() {
+				out = append(out, rest.With(k, slab))
+			}
+		}
+		rest = rest.With(k, mid)
+	}
+	return out
+}
+
+// Cmp is a comparison against a value, used to split boxes.
+type Cmp int
+
+const (
+	Less Cmp = iota
+	LessEq
+	Greater
+	GreaterEq
+)
+
+var cmpNames = []string{"<", "<=", ">", ">="}
+
+func (c Cmp) String() string {
+	if c >= 0 && int(c) < len(cmpNames) {
+		return cmpNames[c]
+	}
+	return fmt.Sprintf("Cmp(%d)", int(c))
+}
+
+// ParseCmp converts "<", "<=", ">" or ">=" to a Cmp.
+func ParseCmp(s string) (Cmp, error) {
+	if i := slices.Index(cmpNames, s); i >= 0 {
+		return Cmp(i), nil
+	}
+	return 0, fmt.Errorf("invalid comparison %q, want one of %s", s, strings.Join(cmpNames, " "))
+}
+
+// Split cuts the box into the points where dimension k compares to v as
+// the comparison says, and the rest. Either may be empty. ok is false if
+// the box doesn't have the dimension, an unbounded dimension can't be held
+// in an Interval[T], so give it bounds with With first.
+func (b Box[K, T]) Split(k K, c Cmp, v T) (match, rest Box[K, T], ok bool) {
+	iv, ok := b.Dim(k)
+	if !ok {
+		return Box[K, T]{}, Box[K, T]{}, false
+	}
+	switch c {
+	case Less:
+		below, above := iv.SplitAt(v)
+		return b.With(k, below), b.With(k, above), true
+	case LessEq:
+		below, above := iv.SplitAt(v + 1)
+		return b.With(k, below), b.With(k, above), true
+	case Greater:
+		below, above := iv.SplitAt(v + 1)
+		return b.With(k, above), b.With(k, below), true
+	case GreaterEq:
+		below, above := iv.SplitAt(v)
+		return b.With(k, above), b.With(k, below), true
+	}
+	panic(fmt.Sprintf("invalid comparison %v", c))
+}
+
+func (b Box[K, T]) String() string {
+	parts := make([]string, len(b.keys))
+	for i, k := range b.keys {
+		parts[i] = fmt.Sprintf("%v:%v", k, b.ivs[i])
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// UnionVolume is the number of points in any of the boxes, counting points
+// where boxes overlap once. The boxes must have the same dimensions.
+func UnionVolume[K comparable, T Integer](boxes []Box[K, T]) *big.Int {
+	var disjoint []Box[K, T]
+	for _, b := range boxes {
+		pieces := []Box[K, T]{b}
+		for _, d := range disjoint {
+			var next []Box[K, T]
+			for _, p := range pieces {
+				next = append(next, p.Subtract(d)...)
+			}
+			pieces = next
+		}
+		disjoint = append(disjoint, pieces...)
+	}
+
+	total := new(big.Int)
+	for _, d := range disjoint {
+		total.Add(total, d.Volume())
+	}
+	return total
+}
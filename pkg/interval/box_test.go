@@ -0,0 +1,123 @@
+package interval_test
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/interval"
+)
+
+func TestBoxSplit(t *testing.T) {
+	all := interval.Closed(1, 4000)
+	b := interval.NewBox[string, int]().With("x", all).With("m", all)
+
+	cases := []struct {
+		cmp   interval.Cmp
+		match interval.Interval[int]
+	}{
+		{interval.Less, interval.Closed(1, 1999)},
+		{interval.LessEq, interval.Closed(1, 2000)},
+		{interval.Greater, interval.Closed(2001, 4000)},
+		{interval.GreaterEq, interval.Closed(2000, 4000)},
+	}
+	for _, tc := range cases {
+		match, rest, ok := b.Split("x", tc.cmp, 2000)
+		if !ok {
+			t.Fatalf("x %v 2000: split failed", tc.cmp)
+		}
+		if got, _ := match.Dim("x"); got != tc.match {
+			t.Errorf("x %v 2000: wrong match, want: %v got: %v", tc.cmp, tc.match, got)
+		}
+		total := new(big.Int).Add(match.Volume(), rest.Volume())
+		if total.Cmp(b.Volume()) != 0 {
+			t.Errorf("x %v 2000: split lost points, want: %v got: %v", tc.cmp, b.Volume(), total)
+		}
+	}
+
+	if _, _, ok := b.Split("a", interval.Less, 5); ok {
+		t.Errorf("split on a missing dimension should fail")
+	}
+
+	if c, err := interval.ParseCmp(">="); err != nil || c != interval.GreaterEq {
+		t.Errorf("wrong comparison, want: %v got: %v %v", interval.GreaterEq, c, err)
+	}
+}
+
+func TestBoxVolume(t *testing.T) {
+	big4 := interval.Closed[int64](1, 1_000_000_000)
+	b := interval.Cube(big4, big4, big4)
+	want, _ := new(big.Int).SetString("1000000000000000000000000000", 10)
+	if got := b.Volume(); got.Cmp(want) != 0 {
+		t.Errorf("wrong volume, want: %v got: %v", want, got)
+	}
+}
+
+func TestBoxPoints(t *testing.T) {
+	b := interval.Cube(interval.New(0, 2), interval.New(5, 7))
+	var got [][]int
+	for p := range b.Points() {
+		got = append(got, p)
+	}
+	want := [][]int{{0, 5}, {0, 6}, {1, 5}, {1, 6}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("wrong points, want: %v got: %v", want, got)
+	}
+}
+
+func TestUnionVolume(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 200; round++ {
+		var boxes []interval.Box[int, int]
+		var grid [16][16][16]bool
+		for n := r.Intn(6); n > 0; n-- {
+			var ivs []interval.Interval[int]
+			for d := 0; d < 3; d++ {
+				start := r.Intn(16)
+				ivs = append(ivs, interval.New(start, start+r.Intn(17-start)))
+			}
+			b := interval.Cube(ivs...)
+			boxes = append(boxes, b)
+			for p := range b.Points() {
+				grid[p[0]][p[1]][p[2]] = true
+			}
+		}
+
+		want := 0
+		for x := range grid {
+			for y := range grid[x] {
+				for z := range grid[x][y] {
+					if grid[x][y][z] {
+						want++
+					}
+				}
+			}
+		}
+		if got := interval.UnionVolume(boxes); got.Int64() != int64(want) {
+			t.Fatalf("round %v: wrong union volume of %v, want: %v got: %v", round, boxes, want, got)
+		}
+	}
+}
+
+func TestBoxSubtract(t *testing.T) {
+	b := interval.Cube(interval.New(0, 10), interval.New(0, 10))
+	hole := interval.Cube(interval.New(3, 5), interval.New(3, 5))
+	pieces := b.Subtract(hole)
+
+	total := new(big.Int)
+	for i, p := range pieces {
+		total.Add(total, p.Volume())
+		if !p.Intersect(hole).Empty() {
+			t.Errorf("piece %v overlaps the hole", p)
+		}
+		for _, q := range pieces[i+1:] {
+			if !p.Intersect(q).Empty() {
+				t.Errorf("pieces %v and %v overlap", p, q)
+			}
+		}
+	}
+	if total.Int64() != 96 {
+		t.Errorf("wrong volume, want: %v got: %v", 96, total)
+	}
+}
@@ -0,0 +1,259 @@
+// Package interval does arithmetic on ranges of integers.
+//
+// An Interval is half-open, it holds Start but not End, so lengths are
+// End-Start and an interval split at a point doesn't share or lose a value.
+// A Set is a union of intervals kept sorted, disjoint and merged, so two
+// sets holding the same values are equal. A Mapping moves ranges of values
+// by offsets, and mappings compose so layers of them become one. A Box is an
+// interval in each of several dimensions.
+package interval
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Integer is the type of the values in an interval.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Interval is the values from Start up to, but not including, End. It is
+// empty when End is not after Start.
+type Interval[T Integer] struct {
+	Start T
+	End   T
+}
+
+// New returns the interval [start, end).
+func New[T Integer](start, end T) Interval[T] {
+	return Interval[T]{Start: start, End: end}
+}
+
+// Closed returns the interval holding first through last, inclusive.
+func Closed[T Integer](first, last T) Interval[T] {
+	return Interval[T]{Start: first, End: last + 1}
+}
+
+// Length returns the interval of n values starting at start.
+func Length[T Integer](start, n T) Interval[T] {
+	return Interval[T]{Start: start, End: start + n}
+}
+
+// Empty reports whether the interval holds no values.
+func (i Interval[T]) Empty() bool {
+	return i.End <= i.Start
+}
+
+// Len is the number of values in the interval.
+func (i Interval[T]) Len() T {
+	if i.Empty() {
+		return 0
+	}
+	return i.End - i.Start
+}
+
+// Last is the largest value in the interval, which must not be empty.
+func (i Interval[T]) Last() T {
+	return i.End - 1
+}
+
+// Contains reports whether v is in the interval.
+func (i Interval[T]) Contains(v T) bool {
+	return v >= i.Start && v < i.End
+}
+
+// Overlaps reports whether the intervals share a value.
+func (i Interval[T]) Overlaps(o Interval[T]) bool {
+	return !i.Intersect(o).Empty()
+}
+
+// Intersect returns the values in both intervals, which may be empty.
+func (i Interval[T]) Intersect(o Interval[T]) Interval[T] {
+	return Interval[T]{Start: max(i.Start, o.Start), End: min(i.End, o.End)}
+}
+
+// SplitAt cuts the interval into the values below v and the values from v
+// on. Either may be empty.
+func (i Interval[T]) SplitAt(v T) (below, above Interval[T]) {
+	v = min(max(v, i.Start), max(i.End, i.Start))
+	return Interval[T]{Start: i.Start, End: v}, Interval[T]{Start: v, End: max(i.End, v)}
+}
+
+// Shift moves the interval by d.
+func (i Interval[T]) Shift(d T) Interval[T] {
+	return Interval[T]{Start: i.Start + d, End: i.End + d}
+}
+
+func (i Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v)", i.Start, i.End)
+}
+
+// Set is a union of intervals. The zero value is the empty set, and the
+// operations return new sets rather than changing their receiver.
+type Set[T Integer] struct {
+	// ivs are sorted, non-empty, and neither overlap nor touch.
+	ivs []Interval[T]
+}
+
+// NewSet returns the union of the intervals.
+func NewSet[T Integer](ivs ...Interval[T]) Set[T] {
+	return Set[T]{ivs: normalize(slices.Clone(ivs))}
+}
+
+// normalize sorts and merges ivs in place.
+func normalize[T Integer](ivs []Interval[T]) []Interval[T] {
+	ivs = slices.DeleteFunc(ivs, Interval[T].Empty)
+	slices.SortFunc(ivs, func(a, b Interval[T]) int {
+		switch {
+		case a.Start < b.Start:
+			return -1
+		case a.Start > b.Start:
+			return 1
+		}
+		return 0
+	})
+
+	out := ivs[:0]
+	for _, iv := range ivs {
+		if n := len(out); n > 0 && iv.Start <= out[n-1].End {
+			out[n-1].End = max(out[n-1].End, iv.End)
+			continue
+		}
+		out = append(out, iv)
+	}
+	return out
+}
+
+// Intervals returns the disjoint intervals of the set in order.
+func (s Set[T]) Intervals() []Interval[T] {
+	return slices.Clone(s.ivs)
+}
+
+// Empty reports whether the set holds no values.
+func (s Set[T]) Empty() bool {
+	return len(s.ivs) == 0
+}
+
+// Len is the number of values in the set.
+func (s Set[T]) Len() T {
+	var n T
+	for _, iv := range s.ivs {
+		n += iv.Len()
+	}
+	return n
+}
+
+// Min is the smallest value in the set, false if it is empty.
+func (s Set[T]) Min() (T, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	return s.ivs[0].Start, true
+}
+
+// Max is the largest value in the set, false if it is empty.
+func (s Set[T]) Max() (T, bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	return s.ivs[len(s.ivs)-1].Last(), true
+}
+
+// Contains reports whether v is in the set.
+func (s Set[T]) Contains(v T) bool {
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].End > v })
+	return i < len(s.ivs) && s.ivs[i].Contains(v)
+}
+
+// Equal reports whether the sets hold the same values.
+func (s Set[T]) Equal(o Set[T]) bool {
+	return slices.Equal(s.ivs, o.ivs)
+}
+
+// Add returns the set with the interval added.
+func (s Set[T]) Add(iv Interval[T]) Set[T] {
+	return s.Union(NewSet(iv))
+}
+
+// Union returns the values in either set.
+func (s Set[T]) Union(o Set[T]) Set[T] {
+	ivs := make([]Interval[T], 0, len(s.ivs)+len(o.ivs))
+	ivs = append(append(ivs, s.ivs...), o.ivs...)
+	return Set[T]{ivs: normalize(ivs)}
+}
+
+// Intersect returns the values in both sets.
+func (s Set[T]) Intersect(o Set[T]) Set[T] {
+	var out []Interval[T]
+	for i, j := 0, 0; i < len(s.ivs) && j < len(o.ivs); {
+		if iv := s.ivs[i].Intersect(o.ivs[j]); !iv.Empty() {
+			out = append(out, iv)
+		}
+		// Move past whichever interval ends first.
+		if s.ivs[i].End < o.ivs[j].End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return Set[T]{ivs: out}
+}
+
+// Difference returns the values in s that are not in o.
+func (s Set[T]) Difference(o Set[T]) Set[T] {
+	var out []Interval[T]
+	j := 0
+	for _, iv := range s.ivs {
+		// Skip the intervals of o that end before this one starts.
+		for j < len(o.ivs) && o.ivs[j].End <= iv.Start {
+			j++
+		}
+		rest := iv
+		for k := j; k < len(o.ivs) && o.ivs[k].Start < rest.End; k++ {
+			below, _ := rest.SplitAt(o.ivs[k].Start)
+			if !below.Empty() {
+				out = append(out, below)
+			}
+			_, rest = rest.SplitAt(o.ivs[k].End)
+		}
+		if !rest.Empty() {
+			out = append(out, rest)
+		}
+	}
+	return Set[T]{ivs: out}
+}
+
+// SplitAt cuts the set into the values below v and the values from v on.
+func (s Set[T]) SplitAt(v T) (below, above Set[T]) {
+	for _, iv := range s.ivs {
+		b, a := iv.SplitAt(v)
+		if !b.Empty() {
+			below.ivs = append(below.ivs, b)
+		}
+		if !a.Empty() {
+			above.ivs = append(above.ivs, a)
+		}
+	}
+	return below, above
+}
+
+// Shift moves every value in the set by d.
+func (s Set[T]) Shift(d T) Set[T] {
+	out := make([]Interval[T], len(s.ivs))
+	for i, iv := range s.ivs {
+		out[i] = iv.Shift(d)
+	}
+	return Set[T]{ivs: out}
+}
+
+func (s Set[T]) String() string {
+	parts := make([]string, len(s.ivs))
+	for i, iv := range s.ivs {
+		parts[i] = iv.String()
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
@@ -0,0 +1,139 @@
+package interval_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/mikehelmick/adventofcode/pkg/interval"
+)
+
+// domain bounds the values in generated sets so they overlap often.
+const domain = 64
+
+// model is a set as a bitmap, the obviously correct version to check
+// against.
+type model [domain]bool
+
+// genSet is a random set made of a few random intervals.
+type genSet struct {
+	ivs []interval.Interval[int]
+}
+
+func (genSet) Generate(r *rand.Rand, _ int) reflect.Value {
+	var g genSet
+	for n := r.Intn(5); n > 0; n-- {
+		start := r.Intn(domain)
+		g.ivs = append(g.ivs, interval.New(start, start+r.Intn(domain-start+1)))
+	}
+	return reflect.ValueOf(g)
+}
+
+func (g genSet) set() interval.Set[int] {
+	return interval.NewSet(g.ivs...)
+}
+
+func (g genSet) model() model {
+	var m model
+	for _, iv := range g.ivs {
+		for v := iv.Start; v < iv.End; v++ {
+			m[v] = true
+		}
+	}
+	return m
+}
+
+// matches checks the set holds exactly the model's values and is in normal
+// form.
+func matches(s interval.Set[int], m model) bool {
+	n := 0
+	for v, in := range m {
+		if s.Contains(v) != in {
+			return false
+		}
+		if in {
+			n++
+		}
+	}
+	if s.Len() != n {
+		return false
+	}
+	ivs := s.Intervals()
+	for i, iv := range ivs {
+		if iv.Empty() || (i > 0 && ivs[i-1].End >= iv.Start) {
+			return false
+		}
+	}
+	return true
+}
+
+func check(t *testing.T, name string, f any) {
+	t.Helper()
+	if err := quick.Check(f, &quick.Config{MaxCount: 500}); err != nil {
+		t.Errorf("%s: %v", name, err)
+	}
+}
+
+func TestSetProperties(t *testing.T) {
+	check(t, "normalized", func(a genSet) bool {
+		return matches(a.set(), a.model())
+	})
+	check(t, "union", func(a, b genSet) bool {
+		ma, mb := a.model(), b.model()
+		var want model
+		for v := range want {
+			want[v] = ma[v] || mb[v]
+		}
+		return matches(a.set().Union(b.set()), want)
+	})
+	check(t, "intersect", func(a, b genSet) bool {
+		ma, mb := a.model(), b.model()
+		var want model
+		for v := range want {
+			want[v] = ma[v] && mb[v]
+		}
+		return matches(a.set().Intersect(b.set()), want)
+	})
+	check(t, "difference", func(a, b genSet) bool {
+		ma, mb := a.model(), b.model()
+		var want model
+		for v := range want {
+			want[v] = ma[v] && !mb[v]
+		}
+		return matches(a.set().Difference(b.set()), want)
+	})
+	check(t, "split", func(a genSet, at uint8) bool {
+		p := int(at) % (domain + 1)
+		m := a.model()
+		var below, above model
+		for v := range m {
+			below[v] = m[v] && v < p
+			above[v] = m[v] && v >= p
+		}
+		b, ab := a.set().SplitAt(p)
+		return matches(b, below) && matches(ab, above) && b.Union(ab).Equal(a.set())
+	})
+	check(t, "difference and intersection partition", func(a, b genSet) bool {
+		s := a.set()
+		in, out := s.Intersect(b.set()), s.Difference(b.set())
+		return in.Intersect(out).Empty() && in.Union(out).Equal(s) && in.Len()+out.Len() == s.Len()
+	})
+}
+
+func TestInterval(t *testing.T) {
+	iv := interval.Closed(1, 4000)
+	if got := iv.Len(); got != 4000 {
+		t.Errorf("wrong length, want: %v got: %v", 4000, got)
+	}
+	below, above := iv.SplitAt(2001)
+	if below != interval.New(1, 2001) || above != interval.New(2001, 4001) {
+		t.Errorf("wrong split, want: [1, 2001) [2001, 4001) got: %v %v", below, above)
+	}
+	if b, a := iv.SplitAt(-5); !b.Empty() || a != iv {
+		t.Errorf("split below the start should leave it whole, got: %v %v", b, a)
+	}
+	if got := interval.NewSet(interval.New(5, 8), interval.New(1, 3), interval.New(3, 4)).String(); got != "{[1, 4) [5, 8)}" {
+		t.Errorf("wrong set, want: %v got: %v", "{[1, 4) [5, 8)}", got)
+	}
+}
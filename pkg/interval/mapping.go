@@ -0,0 +1,193 @@
+package interval
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+)
+
+// Piece moves the values in an interval by an offset.
+type Piece struct {
+	Interval[int64]
+	Offset int64
+}
+
+func (p Piece) String() string {
+	return fmt.Sprintf("%v%+d", p.Interval, p.Offset)
+}
+
+// Mapping is a piecewise offset function on int64. Each piece moves its
+// values by its offset and values outside every piece map to themselves,
+// so the zero Mapping is the identity.
+type Mapping struct {
+	// pieces are sorted, non-empty, disjoint and have non-zero offsets.
+	// Touching pieces have different offsets.
+	pieces []Piece
+}
+
+// NewMapping returns the mapping made of the pieces, which must not
+// overlap.
+func NewMapping(pieces ...Piece) (Mapping, error) {
+	ps := slices.Clone(pieces)
+	slices.SortFunc(ps, comparePieces)
+	for i := 1; i < len(ps); i++ {
+		if ps[i-1].Overlaps(ps[i].Interval) {
+			return Mapping{}, fmt.Errorf("pieces %v and %v overlap", ps[i-1], ps[i])
+		}
+	}
+	return Mapping{pieces: normalizePieces(ps)}, nil
+}
+
+func comparePieces(a, b Piece) int {
+	switch {
+	case a.Start < b.Start:
+		return -1
+	case a.Start > b.Start:
+		return 1
+	}
+	return 0
+}
+
+// normalizePieces drops pieces that change nothing and joins touching
+// pieces with the same offset. ps must be sorted and disjoint.
+func normalizePieces(ps []Piece) []Piece {
+	out := make([]Piece, 0, len(ps))
+	for _, p := range ps {
+		if p.Empty() || p.Offset == 0 {
+			continue
+		}
+		if n := len(out); n > 0 && out[n-1].End == p.Start && out[n-1].Offset == p.Offset {
+			out[n-1].End = p.End
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// ParseMapping reads lines of "destination source length", the way puzzles
+// write a mapping.
+func ParseMapping(lines []parse.Field) (Mapping, error) {
+	pieces := make([]Piece, 0, len(lines))
+	for _, l := range lines {
+		ints, err := l.SplitInts()
+		if err != nil {
+			return Mapping{}, err
+		}
+		if len(ints) != 3 {
+			return Mapping{}, l.Errorf("want destination, source and length, got %q", l.Text)
+		}
+		dest, src, n := int64(ints[0]), int64(ints[1]), int64(ints[2])
+		pieces = append(pieces, Piece{Interval: Length(src, n), Offset: dest - src})
+	}
+	m, err := NewMapping(pieces...)
+	if err != nil && len(lines) > 0 {
+		return Mapping{}, lines[0].Errorf("%w", err)
+	}
+	return m, err
+}
+
+// Pieces returns the pieces in order.
+func (m Mapping) Pieces() []Piece {
+	return slices.Clone(m.pieces)
+}
+
+// Domain is the set of values the mapping moves.
+func (m Mapping) Domain() Set[int64] {
+	ivs := make([]Interval[int64], len(m.pieces))
+	for i, p := range m.pieces {
+		ivs[i] = p.Interval
+	}
+	return NewSet(ivs...)
+}
+
+// Map returns where v goes.
+func (m Mapping) Map(v int64) int64 {
+	i, found := slices.BinarySearchFunc(m.pieces, v, func(p Piece, v int64) int {
+		switch {
+		case p.End <= v:
+			return -1
+		case p.Start > v:
+			return 1
+		}
+		return 0
+	})
+	if found {
+		return v + m.pieces[i].Offset
+	}
+	return v
+}
+
+// Apply returns where each value of the set goes.
+func (m Mapping) Apply(s Set[int64]) Set[int64] {
+	var out []Interval[int64]
+	rest := s
+	for _, p := range m.pieces {
+		piece := NewSet(p.Interval)
+		for _, iv := range s.Intersect(piece).ivs {
+			out = append(out, iv.Shift(p.Offset))
+		}
+		rest = rest.Difference(piece)
+	}
+	return NewSet(append(out, rest.ivs...)...)
+}
+
+// Then returns the mapping that applies m and then n, so
+// m.Then(n).Map(v) is n.Map(m.Map(v)).
+func (m Mapping) Then(n Mapping) Mapping {
+	var pieces []Piece
+	for _, p := range m.pieces {
+		// Follow where this piece lands through the pieces of n.
+		image := NewSet(p.Shift(p.Offset))
+		for _, q := range n.pieces {
+			for _, iv := range image.Intersect(NewSet(q.Interval)).ivs {
+				pieces = append(pieces, Piece{Interval: iv.Shift(-p.Offset), Offset: p.Offset + q.Offset})
+			}
+			image = image.Difference(NewSet(q.Interval))
+		}
+		for _, iv := range image.ivs {
+			pieces = append(pieces, Piece{Interval: iv.Shift(-p.Offset), Offset: p.Offset})
+		}
+	}
+	// Values m leaves alone only move by n.
+	domain := m.Domain()
+	for _, q := range n.pieces {
+		for _, iv := range NewSet(q.Interval).Difference(domain).ivs {
+			pieces = append(pieces, Piece{Interval: iv, Offset: q.Offset})
+		}
+	}
+	slices.SortFunc(pieces, comparePieces)
+	return Mapping{pieces: normalizePieces(pieces)}
+}
+
+// Inverse returns the mapping that undoes m. That needs m to be one to
+// one, so the pieces must land exactly on the values they move.
+func (m Mapping) Inverse() (Mapping, error) {
+	var (
+		pieces []Piece
+		images Set[int64]
+	)
+	for _, p := range m.pieces {
+		image := p.Shift(p.Offset)
+		if images.Intersect(NewSet(image)).Len() > 0 {
+			return Mapping{}, fmt.Errorf("mapping is not invertible, more than one value maps into %v", image)
+		}
+		images = images.Add(image)
+		pieces = append(pieces, Piece{Interval: image, Offset: -p.Offset})
+	}
+	if !images.Equal(m.Domain()) {
+		return Mapping{}, fmt.Errorf("mapping is not invertible, it moves %v onto %v", m.Domain(), images)
+	}
+	slices.SortFunc(pieces, comparePieces)
+	return Mapping{pieces: normalizePieces(pieces)}, nil
+}
+
+func (m Mapping) String() string {
+	parts := make([]string, len(m.pieces))
+	for i, p := range m.pieces {
+		parts[i] = p.String()
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
@@ -0,0 +1,131 @@
+package interval_test
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/interval"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+)
+
+// genMapping is a random mapping with a few pieces in the test domain.
+type genMapping struct {
+	m interval.Mapping
+}
+
+func (genMapping) Generate(r *rand.Rand, _ int) reflect.Value {
+	var pieces []interval.Piece
+	start := int64(r.Intn(8))
+	for start < domain && r.Intn(4) > 0 {
+		n := int64(1 + r.Intn(12))
+		pieces = append(pieces, interval.Piece{Interval: interval.Length(start, n), Offset: int64(r.Intn(41) - 20)})
+		start += n + int64(r.Intn(4))
+	}
+	m, err := interval.NewMapping(pieces...)
+	if err != nil {
+		panic(err)
+	}
+	return reflect.ValueOf(genMapping{m: m})
+}
+
+// genPermutation is a random one to one mapping that shuffles blocks.
+type genPermutation struct {
+	m interval.Mapping
+}
+
+func (genPermutation) Generate(r *rand.Rand, _ int) reflect.Value {
+	var blocks []interval.Interval[int64]
+	for start := int64(0); start < domain; {
+		n := int64(1 + r.Intn(10))
+		blocks = append(blocks, interval.Length(start, n))
+		start += n
+	}
+	order := r.Perm(len(blocks))
+	var pieces []interval.Piece
+	dest := int64(0)
+	for _, i := range order {
+		pieces = append(pieces, interval.Piece{Interval: blocks[i], Offset: dest - blocks[i].Start})
+		dest += blocks[i].Len()
+	}
+	m, err := interval.NewMapping(pieces...)
+	if err != nil {
+		panic(err)
+	}
+	return reflect.ValueOf(genPermutation{m: m})
+}
+
+func TestMappingProperties(t *testing.T) {
+	check(t, "apply", func(g genMapping, a genSet) bool {
+		var want []interval.Interval[int64]
+		for v, in := range a.model() {
+			if in {
+				want = append(want, interval.Length(g.m.Map(int64(v)), 1))
+			}
+		}
+		var s interval.Set[int64]
+		for _, iv := range a.set().Intervals() {
+			s = s.Add(interval.New(int64(iv.Start), int64(iv.End)))
+		}
+		return g.m.Apply(s).Equal(interval.NewSet(want...))
+	})
+	check(t, "then", func(a, b genMapping) bool {
+		c := a.m.Then(b.m)
+		for v := int64(-30); v < domain+30; v++ {
+			if c.Map(v) != b.m.Map(a.m.Map(v)) {
+				return false
+			}
+		}
+		return true
+	})
+	check(t, "inverse", func(p genPermutation) bool {
+		inv, err := p.m.Inverse()
+		if err != nil {
+			return false
+		}
+		for v := int64(-5); v < domain+5; v++ {
+			if inv.Map(p.m.Map(v)) != v {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestParseMapping(t *testing.T) {
+	lines, err := parse.Lines(strings.NewReader("50 98 2\n52 50 48\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := interval.ParseMapping(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for in, want := range map[int64]int64{0: 0, 49: 49, 50: 52, 97: 99, 98: 50, 99: 51, 100: 100} {
+		if got := m.Map(in); got != want {
+			t.Errorf("wrong mapping of %v, want: %v got: %v", in, want, got)
+		}
+	}
+
+	inv, err := m.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := inv.Map(52); got != 50 {
+		t.Errorf("wrong inverse, want: %v got: %v", 50, got)
+	}
+
+	squash, err := interval.NewMapping(interval.Piece{Interval: interval.New[int64](0, 10), Offset: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := squash.Inverse(); err == nil {
+		t.Errorf("expected an error inverting a mapping that is not one to one")
+	}
+
+	lines, _ = parse.Lines(strings.NewReader("50 98 2\n52 97 3\n"))
+	if _, err := interval.ParseMapping(lines); err == nil {
+		t.Errorf("expected an error for overlapping pieces")
+	}
+}
@@ -0,0 +1,164 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+// Stdin is the -i value that reads the input from standard input.
+const Stdin = "-"
+
+// Input is a named puzzle input. Files are opened again for each run, so
+// solutions that scan their input stream it instead of holding all of it.
+type Input struct {
+	Name string
+	Open solver.Opener
+	// spool is the temporary copy of stdin, removed by Close.
+	spool string
+}
+
+// Close removes the copy of stdin, other inputs have nothing to release.
+func (in *Input) Close() error {
+	if in.spool == "" {
+		return nil
+	}
+	return os.Remove(in.spool)
+}
+
+// Close closes each of the inputs.
+func Close(inputs []*Input) error {
+	var errs []error
+	for _, in := range inputs {
+		errs = append(errs, in.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Selection is which inputs to run the day over.
+type Selection struct {
+	// Example is N to use exampleN.txt from the day directory.
+	Example int
+	// Examples selects every example*.txt in the day directory. Unlike the
+	// same glob as a Path it is never matched in the working directory.
+	Examples bool
+	// Path is an explicit file, Stdin, or a glob pattern. Relative paths
+	// that do not exist in the working directory are looked up in the day
+	// directory.
+	Path string
+}
+
+// Resolve finds the selected inputs. With nothing selected that is the
+// day's input.txt. Stdin can only be read once, so it is copied to a
+// temporary file that every run reads, call Close when done with them.
+func (s Selection) Resolve(dayDir string, stdin io.Reader) ([]*Input, error) {
+	if n := countTrue(s.Example != 0, s.Examples, s.Path != ""); n > 1 {
+		return nil, fmt.Errorf("select one of an example, all examples or an input path")
+	}
+
+	switch {
+	case s.Path == Stdin:
+		in, err := spool(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading stdin: %w", err)
+		}
+		return []*Input{in}, nil
+	case s.Path != "":
+		paths, err := s.match(dayDir)
+		if err != nil {
+			return nil, err
+		}
+		return openAll(paths)
+	case s.Example != 0:
+		return openAll([]string{filepath.Join(dayDir, fmt.Sprintf("example%d.txt", s.Example))})
+	case s.Examples:
+		paths, err := filepath.Glob(filepath.Join(dayDir, "example*.txt"))
+		if err != nil {
+			return nil, err
+		}
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("no examples in %s", dayDir)
+		}
+		return openAll(paths)
+	}
+	return openAll([]string{filepath.Join(dayDir, "input.txt")})
+}
+
+// match expands the path, which may be a glob.
+func (s Selection) match(dayDir string) ([]string, error) {
+	candidates := []string{s.Path}
+	if !filepath.IsAbs(s.Path) {
+		candidates = append(candidates, filepath.Join(dayDir, s.Path))
+	}
+
+	if !isGlob(s.Path) {
+		for _, p := range candidates {
+			if _, err := os.Stat(p); err == nil {
+				return []string{p}, nil
+			}
+		}
+		return nil, fmt.Errorf("cannot find input file %q", s.Path)
+	}
+
+	for _, p := range candidates {
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", s.Path, err)
+		}
+		if len(matches) > 0 {
+			return matches, nil
+		}
+	}
+	return nil, fmt.Errorf("no input files match %q", s.Path)
+}
+
+func countTrue(bs ...bool) int {
+	n := 0
+	for _, b := range bs {
+		if b {
+			n++
+		}
+	}
+	return n
+}
+
+func isGlob(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// openAll checks the files can be read, the runs open them again.
+func openAll(paths []string) ([]*Input, error) {
+	inputs := make([]*Input, 0, len(paths))
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read input file: %q %w", p, err)
+		}
+		f.Close()
+		inputs = append(inputs, &Input{Name: p, Open: solver.FromFile(p)})
+	}
+	return inputs, nil
+}
+
+// spool copies r to a temporary file a chunk at a time.
+func spool(r io.Reader) (*Input, error) {
+	f, err := os.CreateTemp("", "aoc-stdin-*.txt")
+	if err != nil {
+		return nil, err
+	}
+	in := &Input{Name: "stdin", Open: solver.FromFile(f.Name()), spool: f.Name()}
+	_, err = io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		in.Close()
+		return nil, err
+	}
+	return in, nil
+}
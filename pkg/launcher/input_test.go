@@ -0,0 +1,134 @@
+package launcher_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/launcher"
+)
+
+func TestResolve(t *testing.T) {
+	dayDir := t.TempDir()
+	for name, content := range map[string]string{
+		"input.txt":    "real",
+		"example1.txt": "one",
+		"example2.txt": "two",
+		"example3.txt": "three",
+	} {
+		if err := os.WriteFile(filepath.Join(dayDir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		name string
+		sel  launcher.Selection
+		want []string
+	}{
+		{"default", launcher.Selection{}, []string{"real"}},
+		{"example", launcher.Selection{Example: 3}, []string{"three"}},
+		{"absolute path", launcher.Selection{Path: filepath.Join(dayDir, "example2.txt")}, []string{"two"}},
+		{"relative to day", launcher.Selection{Path: "example1.txt"}, []string{"one"}},
+		{"stdin", launcher.Selection{Path: launcher.Stdin}, []string{"piped"}},
+		{"glob", launcher.Selection{Path: "example*.txt"}, []string{"one", "two", "three"}},
+		{"examples", launcher.Selection{Examples: true}, []string{"one", "two", "three"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			inputs, err := tc.sel.Resolve(dayDir, strings.NewReader("piped"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer launcher.Close(inputs)
+			got := make([]string, 0, len(inputs))
+			for _, in := range inputs {
+				got = append(got, read(t, in))
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("want: %v got: %v", tc.want, got)
+			}
+		})
+	}
+
+	for _, sel := range []launcher.Selection{
+		{Example: 9},
+		{Path: "missing.txt"},
+		{Path: "nothing*.txt"},
+		{Example: 1, Path: "input.txt"},
+		{Examples: true, Path: "input.txt"},
+		{Examples: true, Example: 1},
+	} {
+		if _, err := sel.Resolve(dayDir, strings.NewReader("")); err == nil {
+			t.Errorf("expected error for %+v", sel)
+		}
+	}
+}
+
+func TestExamplesIgnoreWorkingDir(t *testing.T) {
+	dayDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dayDir, "example1.txt"), []byte("day"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	wd := t.TempDir()
+	if err := os.WriteFile(filepath.Join(wd, "example1.txt"), []byte("working dir"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(wd); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(orig)
+
+	inputs, err := (launcher.Selection{Examples: true}).Resolve(dayDir, strings.NewReader(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) != 1 || read(t, inputs[0]) != "day" {
+		t.Errorf("want the day's example, got: %v", inputs)
+	}
+
+	if _, err := (launcher.Selection{Examples: true}).Resolve(t.TempDir(), strings.NewReader("")); err == nil {
+		t.Errorf("expected error for a day without examples")
+	}
+}
+
+func TestStdinIsReadOnce(t *testing.T) {
+	inputs, err := launcher.Selection{Path: launcher.Stdin}.Resolve(t.TempDir(), strings.NewReader("piped"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each part reads stdin from the start.
+	for i := 0; i < 2; i++ {
+		if got := read(t, inputs[0]); got != "piped" {
+			t.Errorf("read %d, want: piped got: %v", i, got)
+		}
+	}
+	if err := launcher.Close(inputs); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inputs[0].Open(); err == nil {
+		t.Errorf("stdin copy was not removed")
+	}
+}
+
+func read(t *testing.T, in *launcher.Input) string {
+	t.Helper()
+	r, err := in.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := r.(io.Closer); ok {
+		defer c.Close()
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
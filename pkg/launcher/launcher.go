@@ -0,0 +1,142 @@
+// Package launcher is the shared implementation of the per year launchers.
+// It runs the selected parts of a day's solution over the selected inputs.
+package launcher
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/config"
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"go.uber.org/zap"
+)
+
+// Main parses the command line and runs the selected day for the year. The
+// days must already be registered, which the launchers do by importing the
+// year's all package.
+func Main(year int) {
+	cfg, err := config.Load()
+	if err != nil {
+		panic(err)
+	}
+
+	var sel Selection
+	flag.IntVar(&sel.Example, "e", 0, "-e N to run exampleN.txt")
+	flag.StringVar(&sel.Path, "i", "", "-i path to run an input file, - for stdin, or a glob to run each matching file")
+	ex1 := flag.Bool("e1", false, "-e1 to run example 1")
+	ex2 := flag.Bool("e2", false, "-e2 to run example 2")
+	partFlag := flag.String("part", "both", "--part 1|2|both to select which parts to run")
+	part2 := flag.Bool("p2", false, "-p2 to only run part 2, same as --part 2")
+	impl := flag.String("impl", solver.DefaultImpl, "--impl name to run an alternate implementation")
+	var outputs Outputs
+	outputs.RegisterFlags(flag.CommandLine)
+
+	debug := flag.Bool("debug", false, "--debug to enable debug logging.")
+
+	day := flag.Int("d", 1, "-d X for day X")
+	cfg.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if *ex1 {
+		sel.Example = 1
+	} else if *ex2 {
+		sel.Example = 2
+	}
+	if *part2 {
+		*partFlag = "2"
+	}
+	parts, err := solver.ParseParts(*partFlag)
+	if err != nil {
+		panic(err)
+	}
+	if *debug {
+		cfg.LogLevel = "DEBUG"
+	}
+	// Solutions that check logging.IsDebug pick the level up from here.
+	cfg.ExportLogEnv()
+	log, closeLog, err := cfg.Logger()
+	if err != nil {
+		panic(err)
+	}
+	// Solutions that use the default logger share the same log file.
+	logging.SetDefault(log)
+	ctx := logging.WithLogger(context.Background(), log)
+
+	d, ok := solver.Lookup(year, *day)
+	if !ok {
+		panic(fmt.Sprintf("no solution for %d day %d", year, *day))
+	}
+
+	inputs, err := sel.Resolve(cfg.DayDir(year, *day), os.Stdin)
+	if err != nil {
+		panic(err)
+	}
+
+	failed := 0
+	for _, in := range inputs {
+		if len(inputs) > 1 {
+			fmt.Printf("== %s\n", in.Name)
+		}
+		for _, part := range parts {
+			ctx, capture := outputs.Start(ctx)
+			if !run(ctx, log, cfg, d, part, *impl, in) {
+				failed++
+			}
+			saved, err := outputs.Save(capture, in.Name, part, len(inputs) > 1)
+			if err != nil {
+				log.Errorw("saving outputs", "error", err)
+				failed++
+			}
+			if len(saved) > 0 {
+				log.Infow("saved outputs", "paths", saved)
+			}
+		}
+	}
+	if len(inputs) > 1 {
+		fmt.Printf("== ran %d inputs, %d failed\n", len(inputs), failed)
+	}
+	if err := Close(inputs); err != nil {
+		log.Errorw("removing inputs", "error", err)
+	}
+	closeLog()
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// run solves one part and logs the answer, returning false if it failed.
+func run(ctx context.Context, log *zap.SugaredLogger, cfg *config.Config, d *solver.Day, part int, impl string, in *Input) bool {
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Timeout))
+		defer cancel()
+	}
+
+	name := fmt.Sprintf("part%d", part)
+	var res solver.Result
+	if impl == solver.DefaultImpl {
+		res = d.Run(ctx, part, in.Open)
+	} else {
+		f, ok := d.Impl(part, impl)
+		if !ok {
+			log.Errorw(name, "error", fmt.Sprintf("no %q implementation", impl))
+			return false
+		}
+		res = d.RunImpl(ctx, part, f, in.Open)
+	}
+	switch {
+	case errors.Is(res.Err, solver.ErrNotImplemented):
+		log.Infow(name, "answer", res.Err.Error())
+	case res.Err != nil:
+		log.Errorw(name, "error", res.Err, "took", res.Duration)
+		return false
+	default:
+		log.Infow(name, "answer", res.Answer, "took", res.Duration)
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package launcher
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikehelmick/adventofcode/pkg/anim"
+	"github.com/mikehelmick/adventofcode/pkg/threed"
+	"github.com/mikehelmick/adventofcode/pkg/twod"
+)
+
+// Outputs are the files that days can write besides their answers:
+// animations of simulations, drawings of 2D results and models of 3D ones.
+type Outputs struct {
+	// Path is the GIF to write.
+	Path string
+	// FramesPath is the frames file to write, for aoc play.
+	FramesPath string
+	anim.Options
+	// Render is the .png or .svg to draw results to.
+	Render string
+	// Model is the .obj or .ply to export models to.
+	Model string
+}
+
+// RegisterFlags adds the output flags.
+func (o *Outputs) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Path, "gif", "", "-gif out.gif to save an animation of days that record frames, as out.partN.gif")
+	fs.StringVar(&o.FramesPath, "frames", "", "-frames out.frames to save the recorded frames for aoc play, as out.partN.frames")
+	fs.IntVar(&o.Every, "gif-every", 1, "-gif-every N to keep every Nth frame")
+	fs.IntVar(&o.MaxFrames, "gif-max", 1000, "-gif-max N to keep at most N frames, 0 for no limit")
+	fs.IntVar(&o.Scale, "gif-scale", 4, "-gif-scale N to draw each cell as NxN pixels")
+	fs.IntVar(&o.Delay, "gif-delay", 5, "-gif-delay N for N/100ths of a second between frames")
+	fs.StringVar(&o.Render, "render", "", "-render out.png|out.svg to draw the results of days that render them, as out.partN.png")
+	fs.StringVar(&o.Model, "model", "", "-model out.obj|out.ply to export 3D models of days that build them, as out.partN.obj")
+}
+
+// Capture holds what one run of a part produced for the outputs.
+type Capture struct {
+	Recorder *anim.Recorder
+	Renderer *twod.Renderer
+	Exporter *threed.Exporter
+}
+
+// Start returns a context asking solvers for the configured outputs.
+func (o *Outputs) Start(ctx context.Context) (context.Context, *Capture) {
+	c := &Capture{}
+	if o.Path != "" || o.FramesPath != "" {
+		c.Recorder = anim.NewRecorder(o.Options)
+		ctx = anim.WithRecorder(ctx, c.Recorder)
+	}
+	if o.Render != "" {
+		c.Renderer = twod.NewRenderer()
+		ctx = twod.WithRenderer(ctx, c.Renderer)
+	}
+	if o.Model != "" {
+		c.Exporter = threed.NewExporter()
+		ctx = threed.WithExporter(ctx, c.Exporter)
+	}
+	return ctx, c
+}
+
+// PathFor is the file for one part of an input. The part is added before
+// the extension of path, and so is the input's name when several inputs are
+// run.
+func PathFor(path string, input string, part int, multi bool) string {
+	ext := filepath.Ext(path)
+	name := strings.TrimSuffix(path, ext)
+	if multi {
+		base := filepath.Base(input)
+		name += "." + strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return fmt.Sprintf("%s.part%d%s", name, part, ext)
+}
+
+// Save writes what was captured for one part of an input, returning the
+// files written.
+func (o *Outputs) Save(c *Capture, input string, part int, multi bool) ([]string, error) {
+	var saved []string
+	if c.Recorder.Seen() > 0 {
+		for _, out := range []struct {
+			path  string
+			write func(io.Writer) error
+		}{
+			{o.Path, c.Recorder.WriteGIF},
+			{o.FramesPath, c.Recorder.WriteFrames},
+		} {
+			if out.path == "" {
+				continue
+			}
+			path := PathFor(out.path, input, part, multi)
+			if err := writeFile(path, out.write); err != nil {
+				return saved, err
+			}
+			saved = append(saved, path)
+		}
+	}
+
+	canvases := c.Renderer.Canvases()
+	for i, cv := range canvases {
+		path := numbered(PathFor(o.Render, input, part, multi), cv.Title, i, len(canvases))
+		if err := cv.Save(path); err != nil {
+			return saved, err
+		}
+		saved = append(saved, path)
+	}
+
+	models := c.Exporter.Models()
+	for i, m := range models {
+		path := numbered(PathFor(o.Model, input, part, multi), m.Title, i, len(models))
+		if err := m.Save(path); err != nil {
+			return saved, err
+		}
+		saved = append(saved, path)
+	}
+	return saved, nil
+}
+
+// numbered tells apart the files when a part writes more than one, by their
+// title or their position if they don't have one.
+func numbered(path string, title string, i int, n int) string {
+	if n == 1 {
+		return path
+	}
+	if title == "" {
+		title = fmt.Sprint(i + 1)
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + title + ext
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,24 @@
+package launcher_test
+
+import (
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/launcher"
+)
+
+func TestPathFor(t *testing.T) {
+	cases := []struct {
+		input string
+		part  int
+		multi bool
+		want  string
+	}{
+		{"input.txt", 1, false, "/tmp/out.part1.gif"},
+		{"/aoc2024/day14/example2.txt", 2, true, "/tmp/out.example2.part2.gif"},
+	}
+	for _, tc := range cases {
+		if got := launcher.PathFor("/tmp/out.gif", tc.input, tc.part, tc.multi); got != tc.want {
+			t.Errorf("PathFor(%q, %d, %v), want: %v got: %v", tc.input, tc.part, tc.multi, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package leaderboard
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// Supported output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// Write renders the report in the named format.
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatText, "":
+		return r.WriteText(w)
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatCSV:
+		return r.WriteCSV(w)
+	}
+	return fmt.Errorf("unknown format %q, want one of %s, %s, %s", format, FormatText, FormatJSON, FormatCSV)
+}
+
+// WriteText writes the rankings followed by a table of times for each day.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "Rank\tScore\tStars\t\tName\n")
+	for _, s := range r.Standings {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t\t%s\n", s.Rank, s.LocalScore, s.Stars, s.Name)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, day := range r.Days() {
+		fmt.Fprintf(w, "\nDay %d\n", day)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "  Name\tPart 1\tPart 2\tDelta\n")
+		for _, s := range r.Standings {
+			for _, d := range s.Days {
+				if d.Day != day {
+					continue
+				}
+				fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", s.Name, formatDuration(d.Part1), formatDuration(d.Part2), formatDuration(d.Delta()))
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes one row per member and completed day. Members who have
+// not started are written with an empty day.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"rank", "id", "name", "local_score", "stars", "day", "part1_seconds", "part2_seconds", "delta_seconds"})
+	for _, s := range r.Standings {
+		member := []string{strconv.Itoa(s.Rank), strconv.Itoa(s.ID), s.Name, strconv.Itoa(s.LocalScore), strconv.Itoa(s.Stars)}
+		if len(s.Days) == 0 {
+			cw.Write(append(member, "", "", "", ""))
+		}
+		for _, d := range s.Days {
+			row := append(member[:len(member):len(member)], strconv.Itoa(d.Day),
+				csvSeconds(d.Part1), csvSeconds(d.Part2), csvSeconds(d.Delta()))
+			cw.Write(row)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvSeconds(d *time.Duration) string {
+	if d == nil {
+		return ""
+	}
+	return strconv.FormatInt(*seconds(d), 10)
+}
+
+// formatDuration formats as h:mm:ss, with hours growing past 24 for late
+// solves.
+func formatDuration(d *time.Duration) string {
+	if d == nil {
+		return "-"
+	}
+	s := *seconds(d)
+	return fmt.Sprintf("%d:%02d:%02d", s/3600, (s/60)%60, s%60)
+}
@@ -0,0 +1,185 @@
+// Package leaderboard builds reports from private leaderboard JSON.
+package leaderboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Star is a single completed part.
+type Star struct {
+	GetStarTS int64 `json:"get_star_ts"`
+	StarIndex int64 `json:"star_index"`
+}
+
+// Member is a member of the leaderboard as returned by the API.
+type Member struct {
+	ID          int     `json:"id"`
+	Name        *string `json:"name"`
+	Stars       int     `json:"stars"`
+	LocalScore  int     `json:"local_score"`
+	GlobalScore int     `json:"global_score"`
+	LastStarTS  int64   `json:"last_star_ts"`
+	// CompletionDayLevel is keyed by day and then part.
+	CompletionDayLevel map[string]map[string]Star `json:"completion_day_level"`
+}
+
+// DisplayName is the member's name, or the placeholder the site uses for
+// anonymous members.
+func (m *Member) DisplayName() string {
+	if m.Name != nil && *m.Name != "" {
+		return *m.Name
+	}
+	return fmt.Sprintf("(anonymous user #%d)", m.ID)
+}
+
+// Leaderboard is the private leaderboard API response.
+type Leaderboard struct {
+	Event   string             `json:"event"`
+	OwnerID int                `json:"owner_id"`
+	Members map[string]*Member `json:"members"`
+}
+
+// Parse reads the leaderboard JSON.
+func Parse(r io.Reader) (*Leaderboard, error) {
+	var lb Leaderboard
+	if err := json.NewDecoder(r).Decode(&lb); err != nil {
+		return nil, fmt.Errorf("decoding leaderboard: %w", err)
+	}
+	return &lb, nil
+}
+
+// Unlock returns the time the puzzle for the day became available, which
+// is midnight US Eastern time.
+func Unlock(year, day int) time.Time {
+	return time.Date(year, time.December, day, 5, 0, 0, 0, time.UTC)
+}
+
+// DayResult is how long a member took on one day, measured from the time
+// the puzzle unlocked. Parts that were not completed are nil.
+type DayResult struct {
+	Day   int
+	Part1 *time.Duration
+	Part2 *time.Duration
+}
+
+// Delta is the time between completing part 1 and part 2, or nil if both
+// parts are not complete.
+func (d DayResult) Delta() *time.Duration {
+	if d.Part1 == nil || d.Part2 == nil {
+		return nil
+	}
+	delta := *d.Part2 - *d.Part1
+	return &delta
+}
+
+// MarshalJSON reports the durations in seconds.
+func (d DayResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Day   int    `json:"day"`
+		Part1 *int64 `json:"part1_seconds,omitempty"`
+		Part2 *int64 `json:"part2_seconds,omitempty"`
+		Delta *int64 `json:"delta_seconds,omitempty"`
+	}{d.Day, seconds(d.Part1), seconds(d.Part2), seconds(d.Delta())})
+}
+
+// Standing is one member's position on the leaderboard.
+type Standing struct {
+	Rank       int         `json:"rank"`
+	ID         int         `json:"id"`
+	Name       string      `json:"name"`
+	LocalScore int         `json:"local_score"`
+	Stars      int         `json:"stars"`
+	Days       []DayResult `json:"days"`
+}
+
+// Report is the leaderboard ordered by local score.
+type Report struct {
+	Year      int        `json:"year"`
+	Standings []Standing `json:"standings"`
+}
+
+// NewReport ranks the members by local score, breaking ties by stars and
+// then by who got their last star first, like the site does.
+func NewReport(lb *Leaderboard) (*Report, error) {
+	year, err := strconv.Atoi(lb.Event)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event %q: %w", lb.Event, err)
+	}
+
+	members := make([]*Member, 0, len(lb.Members))
+	for _, m := range lb.Members {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		if a.LocalScore != b.LocalScore {
+			return a.LocalScore > b.LocalScore
+		}
+		if a.Stars != b.Stars {
+			return a.Stars > b.Stars
+		}
+		if a.LastStarTS != b.LastStarTS {
+			return a.LastStarTS < b.LastStarTS
+		}
+		return a.ID < b.ID
+	})
+
+	report := &Report{Year: year}
+	for i, m := range members {
+		s := Standing{
+			Rank:       i + 1,
+			ID:         m.ID,
+			Name:       m.DisplayName(),
+			LocalScore: m.LocalScore,
+			Stars:      m.Stars,
+		}
+		for day := 1; day <= 25; day++ {
+			parts, ok := m.CompletionDayLevel[strconv.Itoa(day)]
+			if !ok {
+				continue
+			}
+			unlock := Unlock(year, day)
+			res := DayResult{Day: day}
+			if star, ok := parts["1"]; ok {
+				d := time.Unix(star.GetStarTS, 0).Sub(unlock)
+				res.Part1 = &d
+			}
+			if star, ok := parts["2"]; ok {
+				d := time.Unix(star.GetStarTS, 0).Sub(unlock)
+				res.Part2 = &d
+			}
+			s.Days = append(s.Days, res)
+		}
+		report.Standings = append(report.Standings, s)
+	}
+	return report, nil
+}
+
+// Days returns the days that at least one member has started.
+func (r *Report) Days() []int {
+	seen := make(map[int]bool)
+	for _, s := range r.Standings {
+		for _, d := range s.Days {
+			seen[d.Day] = true
+		}
+	}
+	days := make([]int, 0, len(seen))
+	for d := range seen {
+		days = append(days, d)
+	}
+	sort.Ints(days)
+	return days
+}
+
+func seconds(d *time.Duration) *int64 {
+	if d == nil {
+		return nil
+	}
+	s := int64(*d / time.Second)
+	return &s
+}
@@ -0,0 +1,87 @@
+package leaderboard_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/leaderboard"
+)
+
+func loadReport(t *testing.T) *leaderboard.Report {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", "2023.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lb, err := leaderboard.Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := leaderboard.NewReport(lb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func TestNewReport(t *testing.T) {
+	report := loadReport(t)
+
+	wantNames := []string{"Ada, L.", "Mike", "(anonymous user #300)"}
+	if len(report.Standings) != len(wantNames) {
+		t.Fatalf("wrong number of standings: %+v", report.Standings)
+	}
+	for i, name := range wantNames {
+		if s := report.Standings[i]; s.Name != name || s.Rank != i+1 {
+			t.Errorf("rank %d, want: %v got: %v (%d)", i+1, name, s.Name, s.Rank)
+		}
+	}
+
+	mike := report.Standings[1]
+	if len(mike.Days) != 3 {
+		t.Fatalf("wrong number of days: %+v", mike.Days)
+	}
+	day1 := mike.Days[0]
+	if *day1.Part1 != 5*time.Minute || *day1.Part2 != 20*time.Minute || *day1.Delta() != 15*time.Minute {
+		t.Errorf("wrong day 1 times: %+v", day1)
+	}
+	if day3 := mike.Days[2]; day3.Part2 != nil || day3.Delta() != nil {
+		t.Errorf("part 2 of day 3 is not complete: %+v", day3)
+	}
+
+	if got := report.Days(); len(got) != 3 || got[0] != 1 || got[2] != 3 {
+		t.Errorf("wrong days: %v", got)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	report := loadReport(t)
+
+	cases := []struct {
+		format string
+		want   []string
+	}{
+		{leaderboard.FormatText, []string{"Day 3\n", "Mike     0:05:00  0:20:00  0:15:00", "5:40:00  -"}},
+		{leaderboard.FormatJSON, []string{`"part1_seconds": 300`, `"delta_seconds": 900`, `"rank": 3`}},
+		{leaderboard.FormatCSV, []string{"rank,id,name,", "1,200,\"Ada, L.\",12,4,1,100,200,100\n", "3,300,(anonymous user #300),0,0,,,,\n"}},
+	}
+	for _, tc := range cases {
+		var sb strings.Builder
+		if err := report.Write(&sb, tc.format); err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(sb.String(), want) {
+				t.Errorf("%v output is missing %q\n%s", tc.format, want, sb.String())
+			}
+		}
+	}
+
+	if err := report.Write(&strings.Builder{}, "xml"); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
@@ -2,6 +2,7 @@ package logging
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strings"
 	"sync"
@@ -25,10 +26,10 @@ const (
 )
 
 var (
-	// defaultLogger is the default logger. It is initialized once per package
-	// include upon calling DefaultLogger.
-	defaultLogger     *zap.SugaredLogger
-	defaultLoggerOnce sync.Once
+	// defaultLogger is the default logger. It is set by SetDefault or
+	// initialized from the environment on the first call to DefaultLogger.
+	defaultLogger   *zap.SugaredLogger
+	defaultLoggerMu sync.Mutex
 )
 
 var developmentEncoderConfig = zapcore.EncoderConfig{
@@ -46,6 +47,23 @@ var developmentEncoderConfig = zapcore.EncoderConfig{
 	EncodeCaller:   zapcore.ShortCallerEncoder,
 }
 
+var jsonEncoderConfig = zapcore.EncoderConfig{
+	TimeKey:        "time",
+	LevelKey:       "level",
+	NameKey:        "logger",
+	CallerKey:      "caller",
+	FunctionKey:    zapcore.OmitKey,
+	MessageKey:     "msg",
+	StacktraceKey:  "stacktrace",
+	LineEnding:     zapcore.DefaultLineEnding,
+	EncodeLevel:    zapcore.CapitalLevelEncoder,
+	EncodeTime:     zapcore.ISO8601TimeEncoder,
+	EncodeDuration: zapcore.StringDurationEncoder,
+	EncodeCaller:   zapcore.ShortCallerEncoder,
+}
+
+// FromContext returns the logger in the context, or the default logger if
+// there isn't one.
 func FromContext(ctx context.Context) *zap.SugaredLogger {
 	if logger, ok := ctx.Value(logKey).(*zap.SugaredLogger); ok {
 		return logger
@@ -53,62 +71,82 @@ func FromContext(ctx context.Context) *zap.SugaredLogger {
 	return DefaultLogger()
 }
 
+// WithLogger returns a context that carries the logger.
 func WithLogger(ctx context.Context, logger *zap.SugaredLogger) context.Context {
 	return context.WithValue(ctx, logKey, logger)
 }
 
+// NewLogger returns a console logger at the level. See New for more options.
 func NewLogger(level string) *zap.SugaredLogger {
-	config := &zap.Config{
-		Level:            zap.NewAtomicLevelAt(levelToZapLevel(level)),
-		Development:      true,
-		Encoding:         "console",
-		EncoderConfig:    developmentEncoderConfig,
-		OutputPaths:      []string{"stdout"},
-		ErrorOutputPaths: []string{"stdout"},
-	}
-
-	logger, err := config.Build()
+	logger, _, err := New(Options{Level: level})
 	if err != nil {
-		logger = zap.NewNop()
+		return zap.NewNop().Sugar()
 	}
-
-	return logger.Sugar()
+	return logger
 }
 
 func IsDebug() bool {
-	return os.Getenv("LOG_LEVEL") == levelDebug
+	return os.Getenv(EnvLevel) == levelDebug
 }
 
+// NewLoggerFromEnv returns a logger configured by OptionsFromEnv. Invalid
+// options fall back to a console logger at LOG_LEVEL. The logger is meant
+// to last as long as the program, its log file is never closed.
 func NewLoggerFromEnv() *zap.SugaredLogger {
-	level := os.Getenv("LOG_LEVEL")
-	return NewLogger(level)
+	opts, err := OptionsFromEnv()
+	if err != nil {
+		return NewLogger(opts.Level)
+	}
+	logger, _, err := New(opts)
+	if err != nil {
+		return NewLogger(opts.Level)
+	}
+	return logger
 }
 
-// DefaultLogger returns the default logger for the package.
+// DefaultLogger returns the default logger for the package, the one given
+// to SetDefault or else one from NewLoggerFromEnv.
 func DefaultLogger() *zap.SugaredLogger {
-	defaultLoggerOnce.Do(func() {
+	defaultLoggerMu.Lock()
+	defer defaultLoggerMu.Unlock()
+	if defaultLogger == nil {
 		defaultLogger = NewLoggerFromEnv()
-	})
+	}
 	return defaultLogger
 }
 
-func levelToZapLevel(s string) zapcore.Level {
+// SetDefault makes logger the default logger, so programs that configure
+// their own logger don't have DefaultLogger build another from the
+// environment and open the log file a second time. A nil logger goes back
+// to the one from the environment.
+func SetDefault(logger *zap.SugaredLogger) {
+	defaultLoggerMu.Lock()
+	defer defaultLoggerMu.Unlock()
+	defaultLogger = logger
+}
+
+// ParseLevel converts a level name like DEBUG, WARNING or CRITICAL to
+// zap's, ignoring case. CRITICAL and ALERT are errors, zap's DPanic and
+// Panic levels would panic when logged at, and only EMERGENCY exits.
+func ParseLevel(s string) (zapcore.Level, error) {
 	switch strings.ToUpper(strings.TrimSpace(s)) {
 	case levelDebug:
-		return zapcore.DebugLevel
+		return zapcore.DebugLevel, nil
 	case levelInfo:
-		return zapcore.InfoLevel
+		return zapcore.InfoLevel, nil
 	case levelWarning:
-		return zapcore.WarnLevel
-	case levelError:
-		return zapcore.ErrorLevel
-	case levelCritical:
-		return zapcore.DPanicLevel
-	case levelAlert:
-		return zapcore.PanicLevel
+		return zapcore.WarnLevel, nil
+	case levelError, levelCritical, levelAlert:
+		return zapcore.ErrorLevel, nil
 	case levelEmergency:
-		return zapcore.FatalLevel
+		return zapcore.FatalLevel, nil
 	}
+	return zapcore.WarnLevel, fmt.Errorf("unknown log level %q, want one of %s %s %s %s %s %s %s",
+		s, levelDebug, levelInfo, levelWarning, levelError, levelCritical, levelAlert, levelEmergency)
+}
 
-	return zapcore.WarnLevel
+// levelToZapLevel is ParseLevel with unknown names as WARNING.
+func levelToZapLevel(s string) zapcore.Level {
+	l, _ := ParseLevel(s)
+	return l
 }
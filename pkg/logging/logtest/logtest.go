@@ -0,0 +1,53 @@
+// Package logtest captures logs in tests so they can be asserted on, and
+// keeps them out of the test output.
+package logtest
+
+import (
+	"context"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Logs are the entries captured by a logger from New or WithLogs.
+type Logs struct {
+	*observer.ObservedLogs
+}
+
+// New returns a logger that captures every entry at level and above instead
+// of writing it anywhere.
+func New(level zapcore.Level) (*zap.SugaredLogger, *Logs) {
+	core, logs := observer.New(level)
+	return zap.New(core).Sugar(), &Logs{logs}
+}
+
+// WithLogs installs a logger capturing entries at every level into the
+// context, so that logging.FromContext returns it.
+func WithLogs(ctx context.Context) (context.Context, *Logs) {
+	logger, logs := New(zapcore.DebugLevel)
+	return logging.WithLogger(ctx, logger), logs
+}
+
+// Messages returns the message of every captured entry in order.
+func (l *Logs) Messages() []string {
+	entries := l.All()
+	msgs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		msgs = append(msgs, e.Message)
+	}
+	return msgs
+}
+
+// Message returns the captured entries with the message.
+func (l *Logs) Message(msg string) []observer.LoggedEntry {
+	return l.FilterMessage(msg).All()
+}
+
+// Field returns the value of a field on the entry, including those added
+// with With, and whether it was set.
+func Field(e observer.LoggedEntry, key string) (any, bool) {
+	v, ok := e.ContextMap()[key]
+	return v, ok
+}
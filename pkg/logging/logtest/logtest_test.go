@@ -0,0 +1,34 @@
+package logtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/logging/logtest"
+)
+
+func TestWithLogs(t *testing.T) {
+	ctx, logs := logtest.WithLogs(context.Background())
+
+	log := logging.FromContext(ctx).With("day", 1)
+	log.Debugw("first", "n", 1)
+	log.Infow("second")
+
+	if got := logs.Messages(); len(got) != 2 || got[0] != "first" || got[1] != "second" {
+		t.Fatalf("wrong messages, want: [first second] got: %v", got)
+	}
+	entries := logs.Message("first")
+	if len(entries) != 1 {
+		t.Fatalf("first entries, want: 1 got: %v", len(entries))
+	}
+	if v, ok := logtest.Field(entries[0], "n"); !ok || v != int64(1) {
+		t.Errorf("field n, want: 1 got: %v", v)
+	}
+	if v, ok := logtest.Field(entries[0], "day"); !ok || v != int64(1) {
+		t.Errorf("field day, want: 1 got: %v", v)
+	}
+	if _, ok := logtest.Field(entries[0], "missing"); ok {
+		t.Errorf("missing field should not be set")
+	}
+}
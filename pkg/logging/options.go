@@ -0,0 +1,180 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Environment variables read by OptionsFromEnv.
+const (
+	EnvLevel  = "LOG_LEVEL"
+	EnvFormat = "LOG_FORMAT"
+	EnvFile   = "LOG_FILE"
+	EnvLevels = "LOG_LEVELS"
+	EnvSample = "LOG_SAMPLE"
+)
+
+// Encodings for Options.Format.
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// Options configure the logger built by New.
+type Options struct {
+	// Level is DEBUG, INFO, WARNING, ERROR, ... for loggers that don't
+	// have their own level in Levels.
+	Level string
+	// Format is console or json, console when empty.
+	Format string
+	// File is written to in addition to stdout when set.
+	File string
+	// Levels sets the level for named loggers, so {"dijkstra": "DEBUG"}
+	// debugs a logger created with Named("dijkstra") and any loggers
+	// named below it, like "dijkstra.heap".
+	Levels map[string]string
+	// Sample limits repeated messages, each second only the first Sample
+	// entries with the same level and message are written and then every
+	// Sample-th one after that. Zero disables sampling.
+	Sample int
+}
+
+// OptionsFromEnv reads the options from LOG_LEVEL, LOG_FORMAT, LOG_FILE,
+// LOG_LEVELS (name=LEVEL,name=LEVEL) and LOG_SAMPLE.
+func OptionsFromEnv() (Options, error) {
+	opts := Options{
+		Level:  os.Getenv(EnvLevel),
+		Format: os.Getenv(EnvFormat),
+		File:   os.Getenv(EnvFile),
+	}
+	levels, err := ParseLevels(os.Getenv(EnvLevels))
+	if err != nil {
+		return opts, fmt.Errorf("%s: %w", EnvLevels, err)
+	}
+	opts.Levels = levels
+	if v := os.Getenv(EnvSample); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, fmt.Errorf("%s: %w", EnvSample, err)
+		}
+		opts.Sample = n
+	}
+	return opts, nil
+}
+
+// ParseLevels parses per logger levels in the form "name=LEVEL,name=LEVEL".
+func ParseLevels(s string) (map[string]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	levels := make(map[string]string)
+	for _, kv := range strings.Split(s, ",") {
+		name, level, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok || name == "" || level == "" {
+			return nil, fmt.Errorf("invalid level %q, want name=LEVEL", kv)
+		}
+		levels[name] = level
+	}
+	return levels, nil
+}
+
+// FormatLevels is the inverse of ParseLevels.
+func FormatLevels(levels map[string]string) string {
+	parts := make([]string, 0, len(levels))
+	for name, level := range levels {
+		parts = append(parts, name+"="+level)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// New builds a logger from the options. Call close when done with it to
+// flush the logger and close the log file.
+func New(opts Options) (logger *zap.SugaredLogger, close func(), err error) {
+	var encoder zapcore.Encoder
+	switch strings.ToLower(opts.Format) {
+	case FormatConsole, "":
+		encoder = zapcore.NewConsoleEncoder(developmentEncoderConfig)
+	case FormatJSON:
+		encoder = zapcore.NewJSONEncoder(jsonEncoderConfig)
+	default:
+		return nil, nil, fmt.Errorf("unknown log format %q, want %s or %s", opts.Format, FormatConsole, FormatJSON)
+	}
+
+	paths := []string{"stdout"}
+	if opts.File != "" {
+		paths = append(paths, opts.File)
+	}
+	sink, closeSink, err := zap.Open(paths...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening log output: %w", err)
+	}
+
+	lc := &levelCore{
+		level: levelToZapLevel(opts.Level),
+		names: make(map[string]zapcore.Level, len(opts.Levels)),
+	}
+	lowest := lc.level
+	for name, level := range opts.Levels {
+		l := levelToZapLevel(level)
+		lc.names[name] = l
+		lowest = min(lowest, l)
+	}
+
+	core := zapcore.NewCore(encoder, sink, lowest)
+	if opts.Sample > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, opts.Sample, opts.Sample)
+	}
+	lc.Core = core
+
+	logger = zap.New(lc,
+		zap.AddCaller(),
+		zap.AddStacktrace(zapcore.WarnLevel),
+		zap.ErrorOutput(sink)).Sugar()
+	return logger, func() {
+		logger.Sync()
+		closeSink()
+	}, nil
+}
+
+// levelCore filters entries by the level configured for the logger's name,
+// the wrapped core is built at the lowest of those levels.
+type levelCore struct {
+	zapcore.Core
+	level zapcore.Level
+	names map[string]zapcore.Level
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level, names: c.names}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < c.levelFor(ent.LoggerName) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// levelFor finds the level of the closest configured name, where "a" is
+// the parent of "a.b".
+func (c *levelCore) levelFor(name string) zapcore.Level {
+	for name != "" {
+		if l, ok := c.names[name]; ok {
+			return l
+		}
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return c.level
+}
@@ -0,0 +1,149 @@
+package logging_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+)
+
+func TestLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	log, closeLog, err := logging.New(logging.Options{
+		Level:  "INFO",
+		File:   path,
+		Levels: map[string]string{"dijkstra": "DEBUG"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log.Debugw("root debug")
+	log.Infow("root info")
+	log.Named("dijkstra").Debugw("dijkstra debug")
+	log.Named("dijkstra").Named("heap").Debugw("heap debug")
+	log.Named("other").Debugw("other debug")
+	closeLog()
+
+	got := readLines(t, path)
+	want := []string{"root info", "dijkstra debug", "heap debug"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong messages, want: %v got: %v", want, got)
+	}
+}
+
+func TestSample(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	log, closeLog, err := logging.New(logging.Options{
+		Level:  "DEBUG",
+		Format: logging.FormatJSON,
+		File:   path,
+		Sample: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		log.Debugw("hot loop", "i", i)
+	}
+	log.Infow("done")
+	closeLog()
+
+	// the first 2, then every 2nd of the other 8
+	got := readLines(t, path)
+	if len(got) != 7 || got[6] != "done" {
+		t.Errorf("wrong sampled messages: %v", got)
+	}
+}
+
+func TestCriticalDoesNotPanic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	log, closeLog, err := logging.New(logging.Options{
+		Level:  "CRITICAL",
+		Format: logging.FormatJSON,
+		File:   path,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("logging at CRITICAL panicked: %v", r)
+		}
+	}()
+	log.Warnw("warning")
+	log.Errorw("error")
+	log.DPanicw("critical")
+	closeLog()
+
+	got := readLines(t, path)
+	want := []string{"error", "critical"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong messages, want: %v got: %v", want, got)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	log, closeLog, err := logging.New(logging.Options{Level: "INFO", File: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logging.SetDefault(log)
+	defer logging.SetDefault(nil)
+
+	logging.DefaultLogger().Infow("from default")
+	closeLog()
+
+	got := readLines(t, path)
+	want := []string{"from default"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrong messages, want: %v got: %v", want, got)
+	}
+}
+
+func TestParseLevels(t *testing.T) {
+	levels, err := logging.ParseLevels("dijkstra=DEBUG, day05=ERROR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"dijkstra": "DEBUG", "day05": "ERROR"}
+	if !reflect.DeepEqual(levels, want) {
+		t.Errorf("wrong levels, want: %v got: %v", want, levels)
+	}
+	if got := logging.FormatLevels(levels); got != "day05=ERROR,dijkstra=DEBUG" {
+		t.Errorf("wrong format, got: %v", got)
+	}
+	if _, err := logging.ParseLevels("dijkstra"); err == nil {
+		t.Errorf("expected error for missing level")
+	}
+	if _, _, err := logging.New(logging.Options{Format: "xml"}); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
+
+// readLines returns the message of each line in the log file, which works
+// for both the console and JSON formats.
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msgs []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if i := strings.Index(line, `"msg":"`); i >= 0 {
+			msg := line[i+len(`"msg":"`):]
+			msgs = append(msgs, msg[:strings.Index(msg, `"`)])
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		msgs = append(msgs, fields[len(fields)-1])
+	}
+	return msgs
+}
@@ -0,0 +1,186 @@
+// Package memo caches the results of functions by their arguments.
+//
+// Keys are any comparable type. Functions of several arguments can use a
+// Pair or Triple, or their own struct, as the key rather than formatting
+// the arguments into a string.
+package memo
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Options configure a cache.
+type Options struct {
+	// Safe guards the cache with a mutex so it can be shared by goroutines.
+	Safe bool
+	// MaxEntries bounds the cache by evicting the least recently used
+	// entry, zero is no limit.
+	MaxEntries int
+}
+
+// Stats count how the cache has been used.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+func (s Stats) String() string {
+	rate := 0.0
+	if total := s.Hits + s.Misses; total > 0 {
+		rate = 100 * float64(s.Hits) / float64(total)
+	}
+	return fmt.Sprintf("%d hits, %d misses (%.1f%% hit rate), %d evictions", s.Hits, s.Misses, rate, s.Evictions)
+}
+
+// Pair is a key of two values.
+type Pair[A, B comparable] struct {
+	A A
+	B B
+}
+
+// Triple is a key of three values.
+type Triple[A, B, C comparable] struct {
+	A A
+	B B
+	C C
+}
+
+// Cache maps keys to the values computed for them.
+type Cache[K comparable, V any] struct {
+	opts Options
+	mu   sync.Mutex
+
+	// values is used when the cache is unbounded.
+	values map[K]V
+	// entries and order are used when it is bounded, the most recently
+	// used entry is at the front of order.
+	entries map[K]*list.Element
+	order   *list.List
+
+	hits, misses, evictions atomic.Uint64
+}
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// New returns an empty cache.
+func New[K comparable, V any](opts Options) *Cache[K, V] {
+	c := &Cache[K, V]{opts: opts}
+	if opts.MaxEntries > 0 {
+		c.entries = make(map[K]*list.Element)
+		c.order = list.New()
+	} else {
+		c.values = make(map[K]V)
+	}
+	return c
+}
+
+func (c *Cache[K, V]) lock() {
+	if c.opts.Safe {
+		c.mu.Lock()
+	}
+}
+
+func (c *Cache[K, V]) unlock() {
+	if c.opts.Safe {
+		c.mu.Unlock()
+	}
+}
+
+// Get returns the value for the key and whether it was found.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.lock()
+	defer c.unlock()
+
+	var (
+		v  V
+		ok bool
+	)
+	if c.order == nil {
+		v, ok = c.values[key]
+	} else if e, found := c.entries[key]; found {
+		c.order.MoveToFront(e)
+		v, ok = e.Value.(*entry[K, V]).value, true
+	}
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return v, ok
+}
+
+// Set stores the value for the key.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.lock()
+	defer c.unlock()
+
+	if c.order == nil {
+		c.values[key] = value
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+	for c.order.Len() > c.opts.MaxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry[K, V]).key)
+		c.evictions.Add(1)
+	}
+}
+
+// Do returns the cached value for the key, or computes it with f and
+// caches it. The cache isn't locked while f runs so f may use the cache,
+// which means goroutines that miss the same key at once each run f.
+func (c *Cache[K, V]) Do(key K, f func() V) V {
+	if v, ok := c.Get(key); ok {
+		return v
+	}
+	v := f()
+	c.Set(key, v)
+	return v
+}
+
+// Len is the number of cached values.
+func (c *Cache[K, V]) Len() int {
+	c.lock()
+	defer c.unlock()
+	if c.order == nil {
+		return len(c.values)
+	}
+	return c.order.Len()
+}
+
+// Stats returns the counts so far.
+func (c *Cache[K, V]) Stats() Stats {
+	return Stats{Hits: c.hits.Load(), Misses: c.misses.Load(), Evictions: c.evictions.Load()}
+}
+
+// Func returns f memoized, along with its cache.
+func Func[K comparable, V any](opts Options, f func(K) V) (func(K) V, *Cache[K, V]) {
+	c := New[K, V](opts)
+	return func(key K) V {
+		return c.Do(key, func() V { return f(key) })
+	}, c
+}
+
+// Recursive memoizes a recursive function. f is given the memoized
+// function to make its recursive calls with, so they are cached too.
+func Recursive[K comparable, V any](opts Options, f func(self func(K) V, key K) V) (func(K) V, *Cache[K, V]) {
+	c := New[K, V](opts)
+	var self func(K) V
+	self = func(key K) V {
+		return c.Do(key, func() V { return f(self, key) })
+	}
+	return self, c
+}
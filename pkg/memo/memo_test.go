@@ -0,0 +1,87 @@
+package memo_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/memo"
+)
+
+func TestRecursive(t *testing.T) {
+	calls := 0
+	fib, cache := memo.Recursive(memo.Options{}, func(self func(int) int, n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return self(n-1) + self(n-2)
+	})
+
+	if got, want := fib(90), 2880067194370816120; got != want {
+		t.Errorf("wrong fib, want: %v got: %v", want, got)
+	}
+	if calls != 91 {
+		t.Errorf("wrong number of calls, want: %v got: %v", 91, calls)
+	}
+	if got, want := cache.Stats(), (memo.Stats{Hits: 88, Misses: 91}); got != want {
+		t.Errorf("wrong stats, want: %v got: %v", want, got)
+	}
+}
+
+func TestTupleKey(t *testing.T) {
+	add, _ := memo.Func(memo.Options{}, func(k memo.Pair[string, int]) int {
+		return len(k.A) + k.B
+	})
+	if got := add(memo.Pair[string, int]{A: "abc", B: 2}); got != 5 {
+		t.Errorf("wrong value, want: %v got: %v", 5, got)
+	}
+}
+
+func TestLRU(t *testing.T) {
+	cache := memo.New[int, string](memo.Options{MaxEntries: 2})
+	cache.Set(1, "one")
+	cache.Set(2, "two")
+	// Using 1 makes 2 the least recently used.
+	cache.Get(1)
+	cache.Set(3, "three")
+
+	if _, ok := cache.Get(2); ok {
+		t.Errorf("2 should have been evicted")
+	}
+	for _, k := range []int{1, 3} {
+		if _, ok := cache.Get(k); !ok {
+			t.Errorf("%v should be cached", k)
+		}
+	}
+	if got := cache.Len(); got != 2 {
+		t.Errorf("wrong length, want: %v got: %v", 2, got)
+	}
+	if got := cache.Stats().Evictions; got != 1 {
+		t.Errorf("wrong evictions, want: %v got: %v", 1, got)
+	}
+}
+
+func TestSafe(t *testing.T) {
+	square, cache := memo.Func(memo.Options{Safe: true, MaxEntries: 50}, func(n int) int { return n * n })
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if got := square(i % 100); got != (i%100)*(i%100) {
+					t.Errorf("wrong square of %v, got: %v", i%100, got)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := cache.Len(); got > 50 {
+		t.Errorf("cache is over its bound, want: <= %v got: %v", 50, got)
+	}
+	if s := cache.Stats(); s.Hits+s.Misses != 8000 {
+		t.Errorf("wrong number of lookups, want: %v got: %v", 8000, s.Hits+s.Misses)
+	}
+}
@@ -0,0 +1,198 @@
+// Package parallel runs a function over the items of a slice or sequence
+// with a bounded pool of workers.
+//
+// The first error returned by the function cancels the context given to
+// the remaining calls and is returned once the workers stop. Cancelling the
+// caller's context stops handing out items and returns the context's error.
+// A panic in a worker is raised again in the caller, with the worker's
+// stack, so the solver's panic handling still sees it.
+package parallel
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"sync"
+)
+
+// Options configure a parallel run.
+type Options struct {
+	// Workers is how many items are handled at once, zero is GOMAXPROCS.
+	Workers int
+}
+
+func (o Options) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+type item[T any] struct {
+	index int
+	value T
+}
+
+// workerPanic carries a worker's panic back to the caller.
+type workerPanic struct {
+	value any
+	stack []byte
+}
+
+func (p *workerPanic) String() string {
+	return fmt.Sprintf("%v\n\nworker %s", p.value, p.stack)
+}
+
+// run calls f for each item in seq on one of the workers. f is given the
+// worker number, below opts.workers(), and the position of the item in seq.
+func run[T any](ctx context.Context, opts Options, seq iter.Seq[T], f func(ctx context.Context, worker, index int, v T) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		once     sync.Once
+		firstErr error
+		panicked *workerPanic
+	)
+	fail := func(err error, p *workerPanic) {
+		once.Do(func() {
+			firstErr, panicked = err, p
+			cancel(err)
+		})
+	}
+
+	items := make(chan item[T])
+	var wg sync.WaitGroup
+	for w := range opts.workers() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					fail(fmt.Errorf("panic: %v", r), &workerPanic{value: r, stack: debug.Stack()})
+				}
+			}()
+			for it := range items {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := f(ctx, w, it.index, it.value); err != nil {
+					fail(err, nil)
+				}
+			}
+		}()
+	}
+
+	i := 0
+feed:
+	for v := range seq {
+		select {
+		case items <- item[T]{index: i, value: v}:
+			i++
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(items)
+	wg.Wait()
+
+	if panicked != nil {
+		panic(panicked.String())
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	return context.Cause(ctx)
+}
+
+// Map calls f for each item and returns the results in the same order.
+func Map[T, R any](ctx context.Context, opts Options, in []T, f func(context.Context, T) (R, error)) ([]R, error) {
+	out := make([]R, len(in))
+	err := run(ctx, opts, slices.Values(in), func(ctx context.Context, _, i int, v T) error {
+		r, err := f(ctx, v)
+		out[i] = r
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MapSeq calls f for each item in seq and returns the results in the order
+// seq yielded the items.
+func MapSeq[T, R any](ctx context.Context, opts Options, seq iter.Seq[T], f func(context.Context, T) (R, error)) ([]R, error) {
+	var (
+		mu  sync.Mutex
+		out []R
+	)
+	err := run(ctx, opts, seq, func(ctx context.Context, _, i int, v T) error {
+		r, err := f(ctx, v)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if i >= len(out) {
+			out = append(out, make([]R, i+1-len(out))...)
+		}
+		out[i] = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Reduce calls f for each item and combines the results. Each worker folds
+// its own results starting from zero, and the workers' totals are then
+// folded together, so zero must be the identity of combine and combine must
+// not depend on the order of its arguments, like a sum or max.
+func Reduce[T, A any](ctx context.Context, opts Options, in []T, zero A, f func(context.Context, T) (A, error), combine func(A, A) A) (A, error) {
+	return ReduceSeq(ctx, opts, slices.Values(in), zero, f, combine)
+}
+
+// ReduceSeq is Reduce over the items in seq.
+func ReduceSeq[T, A any](ctx context.Context, opts Options, seq iter.Seq[T], zero A, f func(context.Context, T) (A, error), combine func(A, A) A) (A, error) {
+	totals := make([]A, opts.workers())
+	for w := range totals {
+		totals[w] = zero
+	}
+	err := run(ctx, opts, seq, func(ctx context.Context, w, _ int, v T) error {
+		a, err := f(ctx, v)
+		if err != nil {
+			return err
+		}
+		totals[w] = combine(totals[w], a)
+		return nil
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	result := zero
+	for _, t := range totals {
+		result = combine(result, t)
+	}
+	return result, nil
+}
+
+// Count returns how many items match.
+func Count[T any](ctx context.Context, opts Options, in []T, match func(context.Context, T) (bool, error)) (int, error) {
+	return CountSeq(ctx, opts, slices.Values(in), match)
+}
+
+// CountSeq returns how many items in seq match.
+func CountSeq[T any](ctx context.Context, opts Options, seq iter.Seq[T], match func(context.Context, T) (bool, error)) (int, error) {
+	return ReduceSeq(ctx, opts, seq, 0, func(ctx context.Context, v T) (int, error) {
+		ok, err := match(ctx, v)
+		if ok {
+			return 1, err
+		}
+		return 0, err
+	}, func(a, b int) int { return a + b })
+}
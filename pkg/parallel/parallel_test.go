@@ -0,0 +1,130 @@
+package parallel_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/parallel"
+)
+
+func square(_ context.Context, i int) (int, error) {
+	return i * i, nil
+}
+
+func TestMap(t *testing.T) {
+	in := make([]int, 1000)
+	for i := range in {
+		in[i] = i
+	}
+
+	for _, workers := range []int{0, 1, 7} {
+		opts := parallel.Options{Workers: workers}
+		got, err := parallel.Map(context.Background(), opts, in, square)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range got {
+			if v != i*i {
+				t.Errorf("workers %v, elem %v is wrong, want: %v got: %v", workers, i, i*i, v)
+			}
+		}
+
+		got, err = parallel.MapSeq(context.Background(), opts, slices.Values(in), square)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(in) {
+			t.Errorf("workers %v, wrong length, want: %v got: %v", workers, len(in), len(got))
+		}
+		for i, v := range got {
+			if v != i*i {
+				t.Errorf("workers %v, seq elem %v is wrong, want: %v got: %v", workers, i, i*i, v)
+			}
+		}
+	}
+}
+
+func TestReduceAndCount(t *testing.T) {
+	ctx := context.Background()
+	opts := parallel.Options{Workers: 4}
+	in := map[string]int{"a": 3, "b": 9, "c": 4, "d": 1}
+
+	biggest, err := parallel.ReduceSeq(ctx, opts, maps.Values(in), 0, func(_ context.Context, v int) (int, error) {
+		return v, nil
+	}, func(a, b int) int { return max(a, b) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if biggest != 9 {
+		t.Errorf("wrong max, want: %v got: %v", 9, biggest)
+	}
+
+	odd, err := parallel.Count(ctx, opts, []int{1, 2, 3, 4, 5}, func(_ context.Context, v int) (bool, error) {
+		return v%2 == 1, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if odd != 3 {
+		t.Errorf("wrong count, want: %v got: %v", 3, odd)
+	}
+}
+
+func TestFirstError(t *testing.T) {
+	want := errors.New("bad item")
+	var calls atomic.Int32
+	_, err := parallel.MapSeq(context.Background(), parallel.Options{Workers: 2}, func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}, func(ctx context.Context, i int) (int, error) {
+		calls.Add(1)
+		if i == 10 {
+			return 0, want
+		}
+		return i, nil
+	})
+	if !errors.Is(err, want) {
+		t.Errorf("wrong error, want: %v got: %v", want, err)
+	}
+	if calls.Load() > 100 {
+		t.Errorf("items kept being handed out after the error, got %v calls", calls.Load())
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := parallel.Count(ctx, parallel.Options{Workers: 2}, make([]int, 1000), func(ctx context.Context, _ int) (bool, error) {
+		cancel()
+		return true, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("wrong error, want: %v got: %v", context.Canceled, err)
+	}
+}
+
+func TestPanic(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("worker panic was not raised again")
+		}
+		if !strings.Contains(fmt.Sprint(r), "boom") {
+			t.Errorf("wrong panic, want: boom got: %v", r)
+		}
+	}()
+	parallel.Map(context.Background(), parallel.Options{}, []int{1, 2, 3}, func(_ context.Context, i int) (int, error) {
+		if i == 2 {
+			panic("boom")
+		}
+		return i, nil
+	})
+}
@@ -0,0 +1,59 @@
+package parse
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Grid converts each rune of the lines to a cell, by row and then column.
+// Every line must be as wide as the first, and an error from cell is
+// reported at the rune's position.
+func Grid[T any](lines []Field, cell func(rune) (T, error)) ([][]T, error) {
+	grid := make([][]T, 0, len(lines))
+	width := -1
+	for _, l := range lines {
+		if n := utf8.RuneCountInString(l.Text); width < 0 {
+			width = n
+		} else if n != width {
+			return nil, l.Errorf("row is %d wide, want %d", n, width)
+		}
+
+		row := make([]T, 0, width)
+		col := 0
+		for _, r := range l.Text {
+			v, err := cell(r)
+			if err != nil {
+				return nil, &Error{Line: l.Line, Col: l.Col + col, Err: err}
+			}
+			row = append(row, v)
+			col++
+		}
+		grid = append(grid, row)
+	}
+	return grid, nil
+}
+
+// Runes is a Grid cell that keeps the rune.
+func Runes(r rune) (rune, error) {
+	return r, nil
+}
+
+// Digit is a Grid cell for the digits 0 to 9.
+func Digit(r rune) (int, error) {
+	if r < '0' || r > '9' {
+		return 0, fmt.Errorf("invalid digit %q", r)
+	}
+	return int(r - '0'), nil
+}
+
+// OneOf returns a Grid cell that accepts only the runes in valid.
+func OneOf(valid string) func(rune) (rune, error) {
+	return func(r rune) (rune, error) {
+		for _, v := range valid {
+			if r == v {
+				return r, nil
+			}
+		}
+		return 0, fmt.Errorf("invalid cell %q, want one of %q", r, valid)
+	}
+}
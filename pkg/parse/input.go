@@ -0,0 +1,48 @@
+package parse
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"iter"
+	"strings"
+)
+
+// Bytes returns all of the remaining input. When the reader already holds
+// the input in memory, as solver.FromBytes does, its bytes are returned
+// without another copy, so callers must not modify them.
+func Bytes(r io.Reader) ([]byte, error) {
+	if b, ok := r.(*bytes.Buffer); ok {
+		return b.Next(b.Len()), nil
+	}
+	return io.ReadAll(r)
+}
+
+// Scan streams the lines of the input without reading all of it first.
+// Unlike bufio.Scanner there is no limit on the length of a line. A final
+// newline doesn't start another line, and the iteration stops after
+// yielding a read error.
+func Scan(r io.Reader) iter.Seq2[Field, error] {
+	return func(yield func(Field, error) bool) {
+		br, ok := r.(*bufio.Reader)
+		if !ok {
+			br = bufio.NewReader(r)
+		}
+		for n := 1; ; n++ {
+			line, err := br.ReadString('\n')
+			if line != "" {
+				text := strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+				if !yield(Field{Line: n, Col: 1, Text: text}, nil) {
+					return
+				}
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(Field{}, err)
+				return
+			}
+		}
+	}
+}
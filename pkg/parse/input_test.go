@@ -0,0 +1,53 @@
+package parse_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+)
+
+func TestScanLongLines(t *testing.T) {
+	long := strings.Repeat("1,", 200_000)
+	input := "short\r\n" + long + "\n\nlast"
+
+	var got []parse.Field
+	for l, err := range parse.Scan(strings.NewReader(input)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, l)
+	}
+	if len(got) != 4 {
+		t.Fatalf("wrong number of lines, want: %v got: %v", 4, len(got))
+	}
+	if got[0].Text != "short" {
+		t.Errorf("wrong first line, want: %q got: %q", "short", got[0].Text)
+	}
+	if got[1].Text != long {
+		t.Errorf("long line was cut, want: %v bytes got: %v", len(long), len(got[1].Text))
+	}
+	if got[3].Line != 4 || got[3].Text != "last" {
+		t.Errorf("wrong last line, want: 4 %q got: %v %q", "last", got[3].Line, got[3].Text)
+	}
+}
+
+func TestBytes(t *testing.T) {
+	input := []byte("3,4\n")
+	b, err := parse.Bytes(bytes.NewBuffer(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if &b[0] != &input[0] {
+		t.Errorf("buffered input was copied")
+	}
+
+	b, err = parse.Bytes(strings.NewReader("3,4\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "3,4\n" {
+		t.Errorf("wrong bytes, want: %q got: %q", "3,4\n", b)
+	}
+}
@@ -0,0 +1,239 @@
+// Package parse reads puzzle input into lines, sections, numbers and grids,
+// returning errors that point at the line and column of the bad input
+// rather than panicking.
+//
+// Every piece of the input is a Field that remembers where it came from, so
+// anything built from a field can report its position with Errorf. Lines
+// with a fixed layout can be described with a Pattern, which fills structs
+// from them.
+package parse
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Error is a problem with the input at a line and column, both counted from
+// one.
+type Error struct {
+	Line int
+	Col  int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d, col %d: %v", e.Line, e.Col, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Field is a piece of the input, which may be a whole line.
+type Field struct {
+	// Line and Col are where Text starts, counted from one.
+	Line int
+	Col  int
+	Text string
+}
+
+func (f Field) String() string {
+	return f.Text
+}
+
+// Errorf returns an error at the start of the field.
+func (f Field) Errorf(format string, args ...any) error {
+	return &Error{Line: f.Line, Col: f.Col, Err: fmt.Errorf(format, args...)}
+}
+
+// sub is the part of the field from byte start to end.
+func (f Field) sub(start, end int) Field {
+	return Field{
+		Line: f.Line,
+		Col:  f.Col + utf8.RuneCountInString(f.Text[:start]),
+		Text: f.Text[start:end],
+	}
+}
+
+// Trim removes leading and trailing white space.
+func (f Field) Trim() Field {
+	start := len(f.Text) - len(strings.TrimLeftFunc(f.Text, unicode.IsSpace))
+	end := len(strings.TrimRightFunc(f.Text, unicode.IsSpace))
+	if end < start {
+		end = start
+	}
+	return f.sub(start, end)
+}
+
+// Int parses the field as a base 10 integer.
+func (f Field) Int() (int, error) {
+	i, err := strconv.Atoi(f.Text)
+	if err != nil {
+		return 0, f.Errorf("invalid integer %q", f.Text)
+	}
+	return i, nil
+}
+
+// Ints finds every integer in the field, ignoring the text around them. A
+// minus sign directly before the digits is kept unless it follows another
+// digit, so "3-5" is 3 and 5 but "x=-5" is -5.
+func (f Field) Ints() ([]int, error) {
+	var ints []int
+	s := f.Text
+	for i := 0; i < len(s); {
+		if !isDigit(s[i]) {
+			i++
+			continue
+		}
+		start, end := i, i
+		for end < len(s) && isDigit(s[end]) {
+			end++
+		}
+		if start > 0 && s[start-1] == '-' && (start < 2 || !isDigit(s[start-2])) {
+			start--
+		}
+		v, err := f.sub(start, end).Int()
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, v)
+		i = end
+	}
+	return ints, nil
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// Split cuts the field at every occurrence of any of the separators and
+// returns the trimmed pieces in between, leaving out empty ones. Without
+// separators it splits on white space.
+func (f Field) Split(seps ...string) []Field {
+	if len(seps) == 0 {
+		seps = []string{" ", "\t"}
+	}
+	var fields []Field
+	add := func(start, end int) {
+		if piece := f.sub(start, end).Trim(); piece.Text != "" {
+			fields = append(fields, piece)
+		}
+	}
+
+	start := 0
+	for i := 0; i < len(f.Text); {
+		n := 0
+		for _, sep := range seps {
+			if len(sep) > n && strings.HasPrefix(f.Text[i:], sep) {
+				n = len(sep)
+			}
+		}
+		if n == 0 {
+			i++
+			continue
+		}
+		add(start, i)
+		i += n
+		start = i
+	}
+	add(start, len(f.Text))
+	return fields
+}
+
+// SplitInts splits the field like Split and parses each piece as an
+// integer.
+func (f Field) SplitInts(seps ...string) ([]int, error) {
+	fields := f.Split(seps...)
+	ints := make([]int, len(fields))
+	for i, field := range fields {
+		v, err := field.Int()
+		if err != nil {
+			return nil, err
+		}
+		ints[i] = v
+	}
+	return ints, nil
+}
+
+// Cut splits the field around the first sep, like "key: value", trimming
+// both sides.
+func (f Field) Cut(sep string) (key, value Field, err error) {
+	i := strings.Index(f.Text, sep)
+	if i < 0 {
+		return Field{}, Field{}, f.Errorf("missing %q in %q", sep, f.Text)
+	}
+	return f.sub(0, i).Trim(), f.sub(i+len(sep), len(f.Text)).Trim(), nil
+}
+
+// KeyValues parses pairs like "x=1, y=2", where pairs are split on pairSep
+// and keys from values on sep.
+func (f Field) KeyValues(pairSep, sep string) (map[string]Field, error) {
+	pairs := make(map[string]Field)
+	for _, pair := range f.Split(pairSep) {
+		k, v, err := pair.Cut(sep)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := pairs[k.Text]; ok {
+			return nil, k.Errorf("duplicate key %q", k.Text)
+		}
+		pairs[k.Text] = v
+	}
+	return pairs, nil
+}
+
+// Lines reads the input as lines, skipping blank ones.
+func Lines(r io.Reader) ([]Field, error) {
+	all, err := allLines(r)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]Field, 0, len(all))
+	for _, l := range all {
+		if strings.TrimSpace(l.Text) != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines, nil
+}
+
+// Sections reads the input as groups of lines separated by blank lines.
+func Sections(r io.Reader) ([][]Field, error) {
+	all, err := allLines(r)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		sections [][]Field
+		current  []Field
+	)
+	for _, l := range all {
+		if strings.TrimSpace(l.Text) == "" {
+			if len(current) > 0 {
+				sections = append(sections, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, l)
+	}
+	if len(current) > 0 {
+		sections = append(sections, current)
+	}
+	return sections, nil
+}
+
+func allLines(r io.Reader) ([]Field, error) {
+	var lines []Field
+	for l, err := range Scan(r) {
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
@@ -0,0 +1,131 @@
+package parse_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+)
+
+func TestSections(t *testing.T) {
+	input := "a\nb\n\n\nc\r\n\nd\n"
+	sections, err := parse.Sections(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([][]string, len(sections))
+	for i, s := range sections {
+		for _, l := range s {
+			got[i] = append(got[i], l.Text)
+		}
+	}
+	want := [][]string{{"a", "b"}, {"c"}, {"d"}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("wrong sections, want: %v got: %v", want, got)
+	}
+	if l := sections[1][0].Line; l != 5 {
+		t.Errorf("wrong line, want: %v got: %v", 5, l)
+	}
+}
+
+func TestInts(t *testing.T) {
+	cases := map[string][]int{
+		"p=0,4 v=3,-3":            {0, 4, 3, -3},
+		"Button A: X+94, Y+34":    {94, 34},
+		"1-3 a: abcde":            {1, 3},
+		"seeds: 79 14 -55 13":     {79, 14, -55, 13},
+		"no numbers here":         nil,
+		"-12--3 the end is -9000": {-12, -3, -9000},
+	}
+	for in, want := range cases {
+		got, err := parse.Field{Line: 1, Col: 1, Text: in}.Ints()
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+			continue
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("wrong ints for %q, want: %v got: %v", in, want, got)
+		}
+	}
+}
+
+func TestSplitAndCut(t *testing.T) {
+	line := parse.Field{Line: 3, Col: 1, Text: "Game 4: 1 red, 2 green;  6 blue"}
+	key, value, err := line.Cut(":")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Text != "Game 4" {
+		t.Errorf("wrong key, want: %q got: %q", "Game 4", key.Text)
+	}
+
+	var got []string
+	for _, f := range value.Split(", ", ";") {
+		got = append(got, f.Text)
+	}
+	want := []string{"1 red", "2 green", "6 blue"}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("wrong split, want: %v got: %v", want, got)
+	}
+	if col := value.Split(", ", ";")[2].Col; col != 26 {
+		t.Errorf("wrong col, want: %v got: %v", 26, col)
+	}
+
+	kv, err := parse.Field{Line: 1, Col: 1, Text: "x=1, y=-2"}.KeyValues(",", "=")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y, err := kv["y"].Int(); err != nil || y != -2 {
+		t.Errorf("wrong y, want: %v got: %v, %v", -2, y, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	lines, err := parse.Lines(strings.NewReader("12 34\n\n56 7x\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = lines[1].SplitInts()
+
+	var perr *parse.Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("wrong error type, got: %T", err)
+	}
+	if perr.Line != 3 || perr.Col != 4 {
+		t.Errorf("wrong position, want: 3:4 got: %v:%v", perr.Line, perr.Col)
+	}
+	if want := `line 3, col 4: invalid integer "7x"`; err.Error() != want {
+		t.Errorf("wrong message, want: %q got: %q", want, err.Error())
+	}
+
+	if _, _, err := lines[0].Cut("|"); err == nil {
+		t.Errorf("expected an error for a missing separator")
+	}
+}
+
+func TestGrid(t *testing.T) {
+	lines, err := parse.Lines(strings.NewReader("012\n345\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid, err := parse.Grid(lines, parse.Digit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]int{{0, 1, 2}, {3, 4, 5}}; !reflect.DeepEqual(want, grid) {
+		t.Errorf("wrong grid, want: %v got: %v", want, grid)
+	}
+
+	lines, _ = parse.Lines(strings.NewReader("#.#\n.x.\n"))
+	_, err = parse.Grid(lines, parse.OneOf(".#"))
+	if want := `line 2, col 2: invalid cell 'x', want one of ".#"`; err == nil || err.Error() != want {
+		t.Errorf("wrong error, want: %q got: %v", want, err)
+	}
+
+	lines, _ = parse.Lines(strings.NewReader("###\n##\n"))
+	if _, err := parse.Grid(lines, parse.Runes); err == nil {
+		t.Errorf("expected an error for a ragged grid")
+	}
+}
@@ -0,0 +1,408 @@
+package parse
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Pattern is a declarative description of a line, like
+//
+//	p={pos.col},{pos.row} v={velocity.col},{velocity.row}
+//
+// The text outside braces must appear in the line as written, except that a
+// run of spaces matches any run of white space. Each {name} captures the
+// text up to the next literal and stores it in the field of that name, so
+// the pattern above fills
+//
+//	type Robot struct {
+//		Pos      *twod.Pos
+//		Velocity *twod.Pos
+//	}
+//
+// Names match fields ignoring case, or the name in a `parse:"name"` tag,
+// and dots reach into nested structs, allocating nil pointers on the way.
+// {_} captures text that is ignored. Use {{ and }} for literal braces.
+//
+// Fields may be strings, integers, floats, bools or implement
+// encoding.TextUnmarshaler. A slice field is a list, split on white space
+// by default or on sep with {name|sep}. A list of structs or a map gives
+// the pattern of each element after another bar, so
+//
+//	Game {id}: {shows|;|{cubes|,|{value} {key}}}
+//
+// fills a Game with an ID and a Shows slice whose elements have a Cubes
+// map[string]int. In a map element {key} and {value} name the entry.
+type Pattern struct {
+	src   string
+	parts []part
+	re    *regexp.Regexp
+	// prefixes[i] matches the parts up to and including the literal parts[i],
+	// they find where a line stops matching.
+	prefixes map[int]*regexp.Regexp
+}
+
+// part is either a literal or a placeholder.
+type part struct {
+	literal string
+
+	hole    bool
+	name    string
+	sep     string
+	element *Pattern
+}
+
+// Compile parses a pattern.
+func Compile(pattern string) (*Pattern, error) {
+	p := &Pattern{src: pattern, prefixes: make(map[int]*regexp.Regexp)}
+
+	var lit strings.Builder
+	flush := func() {
+		if lit.Len() > 0 {
+			p.parts = append(p.parts, part{literal: lit.String()})
+			lit.Reset()
+		}
+	}
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '{' && strings.HasPrefix(pattern[i:], "{{"), c == '}' && strings.HasPrefix(pattern[i:], "}}"):
+			lit.WriteByte(c)
+			i++
+		case c == '}':
+			return nil, fmt.Errorf("pattern %q: unmatched } at %d", pattern, i+1)
+		case c == '{':
+			end := closingBrace(pattern, i)
+			if end < 0 {
+				return nil, fmt.Errorf("pattern %q: unclosed { at %d", pattern, i+1)
+			}
+			h, err := compileHole(pattern[i+1 : end])
+			if err != nil {
+				return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+			}
+			if n := len(p.parts); n > 0 && p.parts[n-1].hole && lit.Len() == 0 {
+				return nil, fmt.Errorf("pattern %q: {%s} directly follows {%s}", pattern, h.name, p.parts[n-1].name)
+			}
+			flush()
+			p.parts = append(p.parts, h)
+			i = end
+		default:
+			lit.WriteByte(c)
+		}
+	}
+	flush()
+
+	var re strings.Builder
+	re.WriteString("^")
+	for i, pt := range p.parts {
+		if pt.hole {
+			re.WriteString("(.*?)")
+			continue
+		}
+		re.WriteString(literalRegexp(pt.literal))
+		p.prefixes[i] = regexp.MustCompile(re.String())
+	}
+	re.WriteString("$")
+	p.re = regexp.MustCompile(re.String())
+	return p, nil
+}
+
+// MustCompile is Compile for patterns known to be valid, it panics on an
+// error.
+func MustCompile(pattern string) *Pattern {
+	p, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p *Pattern) String() string {
+	return p.src
+}
+
+func closingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func compileHole(s string) (part, error) {
+	name, rest, hasSep := strings.Cut(s, "|")
+	h := part{hole: true, name: strings.TrimSpace(name)}
+	if h.name == "" {
+		return part{}, fmt.Errorf("empty placeholder {%s}", s)
+	}
+	if !hasSep {
+		return h, nil
+	}
+	sep, element, hasElement := strings.Cut(rest, "|")
+	h.sep = sep
+	if hasElement {
+		e, err := Compile(element)
+		if err != nil {
+			return part{}, err
+		}
+		h.element = e
+	}
+	return h, nil
+}
+
+var spaces = regexp.MustCompile(` +`)
+
+func literalRegexp(lit string) string {
+	pieces := spaces.Split(lit, -1)
+	for i, piece := range pieces {
+		pieces[i] = regexp.QuoteMeta(piece)
+	}
+	return strings.Join(pieces, `\s+`)
+}
+
+// Match parses the field with the pattern into dst, a pointer to a struct.
+func (p *Pattern) Match(f Field, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("parse: Match needs a pointer to a struct, got %T", dst)
+	}
+	return p.match(f, structTarget(v.Elem()))
+}
+
+// Into parses the field with the pattern into a new T, which must be a
+// struct.
+func Into[T any](p *Pattern, f Field) (T, error) {
+	var v T
+	err := p.Match(f, &v)
+	return v, err
+}
+
+// Each parses every line with the pattern.
+func Each[T any](p *Pattern, lines []Field) ([]T, error) {
+	out := make([]T, 0, len(lines))
+	for _, l := range lines {
+		v, err := Into[T](p, l)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// target finds the value a placeholder name is stored in.
+type target func(name string) (reflect.Value, error)
+
+func (p *Pattern) match(f Field, find target) error {
+	m := p.re.FindStringSubmatchIndex(f.Text)
+	if m == nil {
+		return p.mismatch(f)
+	}
+
+	group := 1
+	for _, pt := range p.parts {
+		if !pt.hole {
+			continue
+		}
+		capture := f.sub(m[2*group], m[2*group+1]).Trim()
+		group++
+		if pt.name == "_" {
+			continue
+		}
+		dst, err := find(pt.name)
+		if err != nil {
+			return capture.Errorf("pattern %q: %w", p.src, err)
+		}
+		if err := pt.set(capture, dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mismatch explains where the field stops matching the pattern.
+func (p *Pattern) mismatch(f Field) error {
+	matched := 0
+	for i, pt := range p.parts {
+		if pt.hole {
+			continue
+		}
+		loc := p.prefixes[i].FindStringIndex(f.Text)
+		if loc == nil {
+			if i == 0 {
+				return f.Errorf("want %q at the start, pattern %q", pt.literal, p.src)
+			}
+			return f.sub(matched, len(f.Text)).Errorf("missing %q, pattern %q", pt.literal, p.src)
+		}
+		matched = loc[1]
+	}
+	return f.sub(matched, len(f.Text)).Errorf("unexpected %q at the end, pattern %q", f.Text[matched:], p.src)
+}
+
+// set converts the captured text and stores it in dst.
+func (h part) set(f Field, dst reflect.Value) error {
+	if dst.Kind() == reflect.Pointer && dst.Type().Elem().Kind() != reflect.Struct {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+	if dst.CanAddr() {
+		if u, ok := dst.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := u.UnmarshalText([]byte(f.Text)); err != nil {
+				return f.Errorf("invalid %s %q: %w", h.name, f.Text, err)
+			}
+			return nil
+		}
+	}
+
+	switch dst.Kind() {
+	case reflect.Slice:
+		items := f.Split(h.seps()...)
+		list := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := h.setElement(item, list.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(list)
+		return nil
+	case reflect.Map:
+		if h.element == nil {
+			return f.Errorf("{%s} fills a map and needs an element pattern", h.name)
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+		for _, item := range f.Split(h.seps()...) {
+			key := reflect.New(dst.Type().Key()).Elem()
+			value := reflect.New(dst.Type().Elem()).Elem()
+			err := h.element.match(item, func(name string) (reflect.Value, error) {
+				switch {
+				case strings.EqualFold(name, "key"):
+					return key, nil
+				case strings.EqualFold(name, "value"):
+					return value, nil
+				}
+				if rest, ok := strings.CutPrefix(name, "value."); ok {
+					return structTarget(value)(rest)
+				}
+				return reflect.Value{}, fmt.Errorf("map elements have {key} and {value}, not {%s}", name)
+			})
+			if err != nil {
+				return err
+			}
+			dst.SetMapIndex(key, value)
+		}
+		return nil
+	case reflect.Struct, reflect.Pointer:
+		if h.element == nil {
+			return f.Errorf("{%s} fills a struct and needs an element pattern", h.name)
+		}
+		return h.element.match(f, structTarget(dst))
+	}
+	return setScalar(f, h.name, dst)
+}
+
+func (h part) seps() []string {
+	if h.sep == "" {
+		return nil
+	}
+	return []string{h.sep}
+}
+
+func (h part) setElement(f Field, dst reflect.Value) error {
+	if h.element != nil {
+		return h.element.match(f, structTarget(dst))
+	}
+	scalar := part{hole: true, name: h.name}
+	return scalar.set(f, dst)
+}
+
+func setScalar(f Field, name string, dst reflect.Value) error {
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(f.Text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(strings.TrimPrefix(f.Text, "+"), 10, dst.Type().Bits())
+		if err != nil {
+			return f.Errorf("invalid integer %q for {%s}", f.Text, name)
+		}
+		dst.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(strings.TrimPrefix(f.Text, "+"), 10, dst.Type().Bits())
+		if err != nil {
+			return f.Errorf("invalid unsigned integer %q for {%s}", f.Text, name)
+		}
+		dst.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(f.Text, dst.Type().Bits())
+		if err != nil {
+			return f.Errorf("invalid number %q for {%s}", f.Text, name)
+		}
+		dst.SetFloat(v)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(f.Text)
+		if err != nil {
+			return f.Errorf("invalid bool %q for {%s}", f.Text, name)
+		}
+		dst.SetBool(v)
+	default:
+		return f.Errorf("{%s} can't fill a %s", name, dst.Type())
+	}
+	return nil
+}
+
+// structTarget finds fields of v, a struct or a pointer to one, by dotted
+// names.
+func structTarget(v reflect.Value) target {
+	return func(name string) (reflect.Value, error) {
+		cur := v
+		for _, step := range strings.Split(name, ".") {
+			if cur.Kind() == reflect.Pointer {
+				if cur.IsNil() {
+					cur.Set(reflect.New(cur.Type().Elem()))
+				}
+				cur = cur.Elem()
+			}
+			if cur.Kind() != reflect.Struct {
+				return reflect.Value{}, fmt.Errorf("{%s}: %s is not a struct", name, cur.Type())
+			}
+			field, ok := fieldByName(cur, step)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("{%s}: %s has no field %q", name, cur.Type(), step)
+			}
+			cur = field
+		}
+		return cur, nil
+	}
+}
+
+func fieldByName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("parse")
+		if tag == "-" {
+			continue
+		}
+		if tag == name || (tag == "" && strings.EqualFold(sf.Name, name)) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,102 @@
+package parse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+)
+
+type pos struct {
+	Row, Col int
+}
+
+type robot struct {
+	Pos      *pos
+	Velocity pos `parse:"v"`
+}
+
+func TestPattern(t *testing.T) {
+	p := parse.MustCompile("p={pos.col},{pos.row} v={v.col},{v.row}")
+	got, err := parse.Into[robot](p, parse.Field{Line: 1, Col: 1, Text: "p=0,4 v=3,-3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := robot{Pos: &pos{Row: 4, Col: 0}, Velocity: pos{Row: -3, Col: 3}}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("wrong robot, want: %+v got: %+v", want, got)
+	}
+}
+
+func TestPatternLists(t *testing.T) {
+	type show struct {
+		Cubes map[string]int
+	}
+	type game struct {
+		ID    int
+		Shows []show
+		Tags  []string
+	}
+
+	p := parse.MustCompile("Game {id}: {shows|;|{cubes|,|{value} {key}}} [{tags|,}]")
+	lines, err := parse.Lines(strings.NewReader("Game 12:  3 blue, 4 red; 1 red [a, b]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	games, err := parse.Each[game](p, lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []game{{
+		ID: 12,
+		Shows: []show{
+			{Cubes: map[string]int{"blue": 3, "red": 4}},
+			{Cubes: map[string]int{"red": 1}},
+		},
+		Tags: []string{"a", "b"},
+	}}
+	if !reflect.DeepEqual(want, games) {
+		t.Errorf("wrong games, want: %+v got: %+v", want, games)
+	}
+
+	var card struct {
+		Numbers []int
+		Winners []int
+	}
+	err = parse.MustCompile("Card {_}: {numbers} | {winners}").Match(parse.Field{Line: 1, Col: 1, Text: "Card  3:  1 21 | 69  1"}, &card)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{69, 1}; !reflect.DeepEqual(want, card.Winners) {
+		t.Errorf("wrong winners, want: %v got: %v", want, card.Winners)
+	}
+}
+
+func TestPatternErrors(t *testing.T) {
+	p := parse.MustCompile("p={pos.col},{pos.row} v={v.col},{v.row}")
+	cases := map[string]string{
+		"x=0,4 v=3,-3": `line 2, col 1: want "p=" at the start, pattern "p={pos.col},{pos.row} v={v.col},{v.row}"`,
+		"p=0,4 w=3,-3": `line 2, col 5: missing " v=", pattern "p={pos.col},{pos.row} v={v.col},{v.row}"`,
+		"p=0,4 v=3,z":  `line 2, col 11: invalid integer "z" for {v.row}`,
+	}
+	for in, want := range cases {
+		var r robot
+		err := p.Match(parse.Field{Line: 2, Col: 1, Text: in}, &r)
+		if err == nil || err.Error() != want {
+			t.Errorf("wrong error for %q, want: %q got: %v", in, want, err)
+		}
+	}
+
+	var r robot
+	err := parse.MustCompile("{speed}").Match(parse.Field{Line: 1, Col: 1, Text: "1"}, &r)
+	if err == nil || !strings.Contains(err.Error(), `has no field "speed"`) {
+		t.Errorf("wrong error for an unknown field, got: %v", err)
+	}
+
+	for _, bad := range []string{"{a", "a}", "{a}{b}", "{}"} {
+		if _, err := parse.Compile(bad); err == nil {
+			t.Errorf("expected an error compiling %q", bad)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Supported output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Write renders the progress in the named format.
+func (y *Year) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatText, "":
+		return y.WriteText(w)
+	case FormatJSON:
+		return y.WriteJSON(w)
+	}
+	return fmt.Errorf("unknown format %q, want one of %s, %s", format, FormatText, FormatJSON)
+}
+
+// WriteText draws a calendar with a cell per day followed by what each
+// started day is missing.
+func (y *Year) WriteText(w io.Writer) error {
+	started := 0
+	for _, d := range y.Days {
+		if d.Started {
+			started++
+		}
+	}
+	fmt.Fprintf(w, "%d: %d days started, %d parts solved, %d answers recorded\n\n", y.Year, started, y.Solved(), y.Stars())
+
+	for week := 0; week < len(y.Days); week += 5 {
+		row := strings.Builder{}
+		for _, d := range y.Days[week:min(week+5, len(y.Days))] {
+			fmt.Fprintf(&row, "  %2d %s", d.Day, cell(d))
+		}
+		fmt.Fprintln(w, strings.TrimRight(row.String(), " "))
+	}
+	fmt.Fprintf(w, "\n  ** both parts solved  *  one part solved  -- started  ! something missing\n")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := false
+	for _, d := range y.Days {
+		if len(d.Missing) == 0 {
+			continue
+		}
+		if !header {
+			fmt.Fprintf(tw, "\nMissing\n")
+			header = true
+		}
+		fmt.Fprintf(tw, "  day %02d\t%s\n", d.Day, strings.Join(d.Missing, ", "))
+	}
+	return tw.Flush()
+}
+
+// cell is the calendar entry for a day, always 4 characters wide.
+func cell(d *Day) string {
+	var c string
+	switch {
+	case len(d.Solved) == 2:
+		c = "**"
+	case len(d.Solved) == 1:
+		c = "* "
+	case d.Started:
+		c = "--"
+	default:
+		c = "  "
+	}
+	if len(d.Missing) > 0 {
+		return c + "! "
+	}
+	return c + "  "
+}
+
+// WriteJSON writes the progress as indented JSON.
+func (y *Year) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(y)
+}
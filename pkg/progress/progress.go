@@ -0,0 +1,165 @@
+// Package progress reports which days of a year have been worked on by
+// looking at the day directories, the registered solutions and the recorded
+// answers.
+package progress
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mikehelmick/adventofcode/pkg/puzzle"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+// Days is the number of puzzles in an event.
+const Days = 25
+
+// Things that can be missing from a day that has been started.
+const (
+	MissingSolution       = "solution"
+	MissingExamples       = "examples"
+	MissingInput          = "input"
+	MissingAnswers        = "answers"
+	MissingExampleAnswers = "example answers"
+)
+
+// Day is the progress on one day.
+type Day struct {
+	Day int `json:"day"`
+	// Started is set when there is a solution or a day directory.
+	Started bool `json:"started"`
+	// Solved are the parts that have a registered solution.
+	Solved []int `json:"solved"`
+	// Examples are the example files in the day directory.
+	Examples []string `json:"examples"`
+	// HasInput is set when the day directory has an input file.
+	HasInput bool `json:"has_input"`
+	// Answered are the parts with a recorded answer for the input.
+	Answered []int `json:"answered"`
+	// Missing lists what a started day still needs.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// Stars is the number of parts that have a recorded answer.
+func (d *Day) Stars() int {
+	return len(d.Answered)
+}
+
+// Year is the progress on every day of an event.
+type Year struct {
+	Year int    `json:"year"`
+	Days []*Day `json:"days"`
+}
+
+// Solved is the number of parts with a solution.
+func (y *Year) Solved() int {
+	n := 0
+	for _, d := range y.Days {
+		n += len(d.Solved)
+	}
+	return n
+}
+
+// Stars is the number of parts with a recorded answer.
+func (y *Year) Stars() int {
+	n := 0
+	for _, d := range y.Days {
+		n += d.Stars()
+	}
+	return n
+}
+
+// Scan builds the progress for the year. dayDir returns the directory that
+// holds the inputs, examples and answers for a day.
+func Scan(year int, dayDir func(day int) string) (*Year, error) {
+	y := &Year{Year: year}
+	for day := 1; day <= Days; day++ {
+		d, err := scanDay(year, day, dayDir(day))
+		if err != nil {
+			return nil, fmt.Errorf("day %d: %w", day, err)
+		}
+		y.Days = append(y.Days, d)
+	}
+	return y, nil
+}
+
+func scanDay(year, day int, dir string) (*Day, error) {
+	d := &Day{Day: day, Solved: []int{}, Examples: []string{}, Answered: []int{}}
+	if s, ok := solver.Lookup(year, day); ok {
+		d.Started = true
+		for part := 1; part <= 2; part++ {
+			if s.Part(part) != nil {
+				d.Solved = append(d.Solved, part)
+			}
+		}
+	}
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		d.Started = true
+	}
+	if !d.Started {
+		return d, nil
+	}
+
+	examples, err := filepath.Glob(filepath.Join(dir, "example*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	for _, ex := range examples {
+		d.Examples = append(d.Examples, filepath.Base(ex))
+	}
+	sort.Slice(d.Examples, func(i, j int) bool {
+		// example10.txt sorts after example9.txt
+		a, b := d.Examples[i], d.Examples[j]
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	})
+	if _, err := os.Stat(filepath.Join(dir, puzzle.InputFile)); err == nil {
+		d.HasInput = true
+	}
+
+	answers, err := puzzle.LoadAnswers(filepath.Join(dir, puzzle.AnswersFile))
+	if err != nil {
+		return nil, err
+	}
+	for part := 1; part <= 2; part++ {
+		if _, ok := answers.Lookup(puzzle.InputFile, part); ok {
+			d.Answered = append(d.Answered, part)
+		}
+	}
+
+	if len(d.Solved) == 0 {
+		d.Missing = append(d.Missing, MissingSolution)
+	}
+	if len(d.Examples) == 0 {
+		d.Missing = append(d.Missing, MissingExamples)
+	}
+	if !d.HasInput {
+		d.Missing = append(d.Missing, MissingInput)
+	}
+	for _, part := range d.Solved {
+		if _, ok := answers.Lookup(puzzle.InputFile, part); !ok {
+			d.Missing = append(d.Missing, MissingAnswers)
+			break
+		}
+	}
+	for _, ex := range d.Examples {
+		if !hasAnswer(answers, ex) {
+			d.Missing = append(d.Missing, MissingExampleAnswers)
+			break
+		}
+	}
+	return d, nil
+}
+
+func hasAnswer(answers puzzle.Answers, input string) bool {
+	for _, a := range answers {
+		if a.Input == input {
+			return true
+		}
+	}
+	return false
+}
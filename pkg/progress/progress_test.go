@@ -0,0 +1,95 @@
+package progress_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/progress"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+func TestScan(t *testing.T) {
+	part := func(ctx context.Context, r io.Reader) (any, error) { return 0, nil }
+	solver.Register(&solver.Day{Year: 1999, Day: 1, Part1: part, Part2: part})
+	solver.Register(&solver.Day{Year: 1999, Day: 2, Part1: part})
+
+	root := t.TempDir()
+	files := map[string]string{
+		"day01/example1.txt": "1\n",
+		"day01/input.txt":    "1\n",
+		"day01/answers.txt":  "example1.txt 1 1\ninput.txt 1 10\ninput.txt 2 20\n",
+		"day02/example1.txt": "2\n",
+		"day03/example1.txt": "3\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	y, err := progress.Scan(1999, func(day int) string {
+		return filepath.Join(root, fmt.Sprintf("day%02d", day))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(y.Days) != progress.Days {
+		t.Fatalf("wrong number of days, want: %v got: %v", progress.Days, len(y.Days))
+	}
+	if y.Solved() != 3 || y.Stars() != 2 {
+		t.Errorf("wrong totals, want: 3 solved 2 stars got: %v solved %v stars", y.Solved(), y.Stars())
+	}
+
+	cases := []struct {
+		day     int
+		started bool
+		missing []string
+	}{
+		{1, true, nil},
+		{2, true, []string{progress.MissingInput, progress.MissingAnswers, progress.MissingExampleAnswers}},
+		{3, true, []string{progress.MissingSolution, progress.MissingInput, progress.MissingExampleAnswers}},
+		{4, false, nil},
+	}
+	for _, tc := range cases {
+		d := y.Days[tc.day-1]
+		if d.Started != tc.started {
+			t.Errorf("day %d started, want: %v got: %v", tc.day, tc.started, d.Started)
+		}
+		if !reflect.DeepEqual(d.Missing, tc.missing) {
+			t.Errorf("day %d missing, want: %v got: %v", tc.day, tc.missing, d.Missing)
+		}
+	}
+
+	var text bytes.Buffer
+	if err := y.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	if want := "   1 **     2 * !    3 --!    4        5"; !strings.Contains(text.String(), want) {
+		t.Errorf("calendar missing %q:\n%s", want, text.String())
+	}
+
+	var js bytes.Buffer
+	if err := y.Write(&js, progress.FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	var decoded progress.Year
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Days[0], y.Days[0]) {
+		t.Errorf("json round trip, want: %+v got: %+v", y.Days[0], decoded.Days[0])
+	}
+}
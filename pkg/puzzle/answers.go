@@ -0,0 +1,107 @@
+package puzzle
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AnswersFile is the name of the expected answers file in a day directory.
+const AnswersFile = "answers.txt"
+
+// Answer is the expected answer for one part over one input file.
+type Answer struct {
+	Input string
+	Part  int
+	Value string
+}
+
+// Answers is the content of an answers file. Each line has the form
+//
+//	<input file> <part> <answer>
+//
+// Blank lines and lines starting with # are ignored.
+type Answers []Answer
+
+// ReadAnswers parses an answers file.
+func ReadAnswers(r io.Reader) (Answers, error) {
+	var answers Answers
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: want \"<input> <part> <answer>\", got %q", lineNo, line)
+		}
+		part, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid part %q: %w", lineNo, fields[1], err)
+		}
+		answers = append(answers, Answer{Input: fields[0], Part: part, Value: strings.TrimSpace(fields[2])})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// LoadAnswers reads an answers file from disk. A missing file is not an error.
+func LoadAnswers(path string) (Answers, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return ReadAnswers(f)
+}
+
+// Lookup returns the expected answer for the input and part.
+func (a Answers) Lookup(input string, part int) (string, bool) {
+	for _, ans := range a {
+		if ans.Input == input && ans.Part == part {
+			return ans.Value, true
+		}
+	}
+	return "", false
+}
+
+// Set records an answer, replacing any existing answer for the input and part.
+func (a Answers) Set(input string, part int, value string) Answers {
+	for i, ans := range a {
+		if ans.Input == input && ans.Part == part {
+			a[i].Value = value
+			return a
+		}
+	}
+	return append(a, Answer{Input: input, Part: part, Value: value})
+}
+
+// Write writes the answers sorted by input and then part.
+func (a Answers) Write(w io.Writer) error {
+	sorted := make(Answers, len(a))
+	copy(sorted, a)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Input != sorted[j].Input {
+			return sorted[i].Input < sorted[j].Input
+		}
+		return sorted[i].Part < sorted[j].Part
+	})
+	for _, ans := range sorted {
+		if _, err := fmt.Fprintf(w, "%s %d %s\n", ans.Input, ans.Part, ans.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package puzzle
+
+import "fmt"
+
+const (
+	// InputFile is the name of the real puzzle input in a day directory.
+	InputFile = "input.txt"
+	// PageFile is the name of the cached puzzle page in a day directory.
+	PageFile = "puzzle.html"
+)
+
+// ExampleFile is an example input extracted from a puzzle page.
+type ExampleFile struct {
+	Name    string
+	Content string
+}
+
+// ExampleName returns the file name used for the nth example.
+func ExampleName(n int) string {
+	return fmt.Sprintf("example%d.txt", n)
+}
+
+// ExampleFiles returns the example input for each part, named in the
+// same exampleN.txt form as the hand copied examples, along with the
+// expected answers. A part that reuses the previous part's example shares
+// its file. Accepted answers for the real input are included as well.
+func (p *Page) ExampleFiles() ([]ExampleFile, Answers) {
+	var files []ExampleFile
+	var answers Answers
+	for _, part := range p.Parts {
+		content, ok := p.Example(part.Number)
+		if ok {
+			name := ""
+			for _, f := range files {
+				if f.Content == content {
+					name = f.Name
+					break
+				}
+			}
+			if name == "" {
+				name = ExampleName(len(files) + 1)
+				files = append(files, ExampleFile{Name: name, Content: content})
+			}
+			if part.Expected != "" {
+				answers = answers.Set(name, part.Number, part.Expected)
+			}
+		}
+		if part.Answer != "" {
+			answers = answers.Set(InputFile, part.Number, part.Answer)
+		}
+	}
+	return files, answers
+}
@@ -0,0 +1,166 @@
+package puzzle
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	whitespace = regexp.MustCompile(`\s+`)
+	mdEscaper  = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+)
+
+// Markdown renders the puzzle description as Markdown. The title becomes a
+// top level heading and each later part a second level heading. Relative
+// links are resolved against base when it is not nil.
+func (p *Page) Markdown(base *url.URL) string {
+	md := &markdown{base: base}
+	for _, part := range p.Parts {
+		md.heading = 1
+		if part.Number > 1 {
+			md.heading = 2
+		}
+		for c := part.article.FirstChild; c != nil; c = c.NextSibling {
+			md.block(c, "")
+		}
+	}
+	return strings.TrimSpace(md.sb.String()) + "\n"
+}
+
+type markdown struct {
+	sb      strings.Builder
+	base    *url.URL
+	heading int
+}
+
+func (m *markdown) block(n *html.Node, indent string) {
+	switch {
+	case n.Type == html.TextNode:
+		if s := strings.TrimSpace(n.Data); s != "" {
+			m.sb.WriteString(indent + m.inline(n) + "\n\n")
+		}
+	case n.Type != html.ElementNode:
+		return
+	case n.DataAtom == atom.H1 || n.DataAtom == atom.H2 || n.DataAtom == atom.H3:
+		title := strings.Trim(textContent(n), "- ")
+		m.sb.WriteString(strings.Repeat("#", m.heading) + " " + title + "\n\n")
+	case n.DataAtom == atom.P:
+		m.sb.WriteString(indent + strings.TrimSpace(m.inlineChildren(n)) + "\n\n")
+	case n.DataAtom == atom.Pre:
+		text := textContent(n)
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		fence := "```"
+		for strings.Contains(text, fence) {
+			fence += "`"
+		}
+		m.sb.WriteString(indent + fence + "\n")
+		for _, line := range strings.SplitAfter(text, "\n") {
+			if line != "" {
+				m.sb.WriteString(indent + line)
+			}
+		}
+		m.sb.WriteString(indent + fence + "\n\n")
+	case n.DataAtom == atom.Ul || n.DataAtom == atom.Ol:
+		m.list(n, indent)
+		if indent == "" {
+			m.sb.WriteString("\n")
+		}
+	default:
+		m.sb.WriteString(indent + strings.TrimSpace(m.inline(n)) + "\n\n")
+	}
+}
+
+func (m *markdown) list(n *html.Node, indent string) {
+	item := 0
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.DataAtom != atom.Li {
+			continue
+		}
+		item++
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = "1. "
+		}
+
+		var text strings.Builder
+		var nested []*html.Node
+		for c := li.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && (c.DataAtom == atom.Ul || c.DataAtom == atom.Ol) {
+				nested = append(nested, c)
+				continue
+			}
+			if c.Type == html.ElementNode && c.DataAtom == atom.P {
+				text.WriteString(m.inlineChildren(c) + " ")
+				continue
+			}
+			text.WriteString(m.inline(c))
+		}
+		m.sb.WriteString(indent + marker + strings.TrimSpace(text.String()) + "\n")
+		for _, l := range nested {
+			m.list(l, indent+"  ")
+		}
+	}
+}
+
+func (m *markdown) inlineChildren(n *html.Node) string {
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(m.inline(c))
+	}
+	return sb.String()
+}
+
+func (m *markdown) inline(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return mdEscaper.Replace(whitespace.ReplaceAllString(n.Data, " "))
+	}
+	if n.Type != html.ElementNode {
+		return ""
+	}
+	switch n.DataAtom {
+	case atom.Code:
+		code := "`" + textContent(n) + "`"
+		if isEmphasizedCode(n) {
+			return "**" + code + "**"
+		}
+		return code
+	case atom.Em, atom.B, atom.Strong:
+		if isEmphasizedCode(n) {
+			return "**`" + textContent(n) + "`**"
+		}
+		return "**" + m.inlineChildren(n) + "**"
+	case atom.I:
+		return "_" + m.inlineChildren(n) + "_"
+	case atom.A:
+		return "[" + m.inlineChildren(n) + "](" + m.resolve(attr(n, "href")) + ")"
+	case atom.Br:
+		return "  \n"
+	}
+	return m.inlineChildren(n)
+}
+
+func (m *markdown) resolve(href string) string {
+	if m.base == nil {
+		return href
+	}
+	u, err := m.base.Parse(href)
+	if err != nil {
+		return href
+	}
+	return u.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
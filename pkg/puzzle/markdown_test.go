@@ -0,0 +1,42 @@
+package puzzle_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/puzzle"
+)
+
+func TestMarkdown(t *testing.T) {
+	in := `<main><article class="day-desc"><h2>--- Day 7: Lists ---</h2>
+<p>See <a href="/2023/about">the about page</a> and <em>don't</em> use *stars*.</p>
+<ul><li>one <code>1</code></li><li><p>two</p><ul><li>nested</li></ul></li></ul>
+<pre><code>a
+ b
+</code></pre>
+</article></main>`
+	page, err := puzzle.Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, _ := url.Parse("https://adventofcode.com/2023/day/7")
+
+	want := "# Day 7: Lists\n\n" +
+		"See [the about page](https://adventofcode.com/2023/about) and **don't** use \\*stars\\*.\n\n" +
+		"- one `1`\n- two\n  - nested\n\n" +
+		"```\na\n b\n```\n"
+	if got := page.Markdown(base); got != want {
+		t.Errorf("wrong markdown\nwant: %q\ngot:  %q", want, got)
+	}
+}
+
+func TestMarkdownBothParts(t *testing.T) {
+	page := parseFixture(t, "2023-day01.html")
+	md := page.Markdown(nil)
+	for _, want := range []string{"# Day 1: Trebuchet?!\n", "\n## Part Two\n", "produces **`281`**."} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Package puzzle extracts information from cached Advent of Code puzzle pages.
+package puzzle
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Part is one part of a puzzle as it appears on the puzzle page.
+type Part struct {
+	Number int
+	// Examples are the contents of the <pre><code> blocks, in page order.
+	Examples []string
+	// Expected is the last emphasized code value in the part, which is the
+	// answer for the example by convention. Empty if none was found.
+	Expected string
+	// Answer is the accepted answer for the real input, only present on pages
+	// fetched while logged in after the part was solved.
+	Answer string
+
+	article *html.Node
+}
+
+// Page is a parsed puzzle page.
+type Page struct {
+	Title string
+	Parts []*Part
+}
+
+// Parse reads a puzzle page. Only the day-desc articles are considered.
+func Parse(r io.Reader) (*Page, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("html.Parse: %w", err)
+	}
+
+	page := &Page{}
+	for _, article := range findAll(doc, isArticle) {
+		part := &Part{
+			Number:  len(page.Parts) + 1,
+			article: article,
+		}
+		for _, pre := range findAll(article, isElement(atom.Pre)) {
+			part.Examples = append(part.Examples, textContent(pre))
+		}
+		if ems := findAll(article, isEmphasizedCode); len(ems) > 0 {
+			part.Expected = strings.TrimSpace(textContent(ems[len(ems)-1]))
+		}
+		part.Answer = acceptedAnswer(article)
+		if part.Number == 1 {
+			if h2 := findAll(article, isElement(atom.H2)); len(h2) > 0 {
+				page.Title = strings.Trim(textContent(h2[0]), "- ")
+			}
+		}
+		page.Parts = append(page.Parts, part)
+	}
+	if len(page.Parts) == 0 {
+		return nil, fmt.Errorf("no puzzle description found")
+	}
+	return page, nil
+}
+
+// Example returns the example input for the part, which is the first
+// code block in the part. Part two frequently reuses the example from
+// part one, so that is used as a fallback.
+func (p *Page) Example(part int) (string, bool) {
+	for i := part - 1; i >= 0; i-- {
+		if i < len(p.Parts) && len(p.Parts[i].Examples) > 0 {
+			return p.Parts[i].Examples[0], true
+		}
+	}
+	return "", false
+}
+
+// acceptedAnswer looks for the "Your puzzle answer was" paragraph that
+// follows the article.
+func acceptedAnswer(article *html.Node) string {
+	for n := article.NextSibling; n != nil; n = n.NextSibling {
+		if isArticle(n) {
+			break
+		}
+		if n.Type != html.ElementNode || n.DataAtom != atom.P {
+			continue
+		}
+		if !strings.Contains(textContent(n), "Your puzzle answer was") {
+			continue
+		}
+		if codes := findAll(n, isElement(atom.Code)); len(codes) > 0 {
+			return strings.TrimSpace(textContent(codes[0]))
+		}
+	}
+	return ""
+}
+
+func isArticle(n *html.Node) bool {
+	if n.Type != html.ElementNode || n.DataAtom != atom.Article {
+		return false
+	}
+	for _, a := range n.Attr {
+		if a.Key == "class" && strings.Contains(a.Val, "day-desc") {
+			return true
+		}
+	}
+	return false
+}
+
+func isElement(a atom.Atom) func(*html.Node) bool {
+	return func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.DataAtom == a
+	}
+}
+
+// isEmphasizedCode matches <code><em>x</em></code> and <em><code>x</code></em>.
+func isEmphasizedCode(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	var inner atom.Atom
+	switch n.DataAtom {
+	case atom.Code:
+		inner = atom.Em
+	case atom.Em:
+		inner = atom.Code
+	default:
+		return false
+	}
+	c := n.FirstChild
+	return c != nil && c.NextSibling == nil && c.Type == html.ElementNode && c.DataAtom == inner
+}
+
+// findAll returns the nodes under n that match f in document order. The
+// children of a matching node are not searched.
+func findAll(n *html.Node, f func(*html.Node) bool) []*html.Node {
+	var found []*html.Node
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if f(c) {
+				found = append(found, c)
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(n)
+	return found
+}
+
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
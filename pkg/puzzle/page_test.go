@@ -0,0 +1,105 @@
+package puzzle_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/puzzle"
+)
+
+func parseFixture(t *testing.T, name string) *puzzle.Page {
+	t.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	page, err := puzzle.Parse(f)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return page
+}
+
+func TestExampleFilesBothParts(t *testing.T) {
+	page := parseFixture(t, "2023-day01.html")
+	if want := "Day 1: Trebuchet?!"; page.Title != want {
+		t.Errorf("wrong title, want: %q got: %q", want, page.Title)
+	}
+
+	files, answers := page.ExampleFiles()
+	if len(files) != 2 {
+		t.Fatalf("wrong number of examples, want: 2 got: %v", len(files))
+	}
+	for i, name := range []string{"example1.txt", "example2.txt"} {
+		want, err := os.ReadFile(filepath.Join("..", "..", "aoc2023", "day01", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if files[i].Name != name {
+			t.Errorf("wrong name, want: %v got: %v", name, files[i].Name)
+		}
+		if files[i].Content != string(want) {
+			t.Errorf("wrong content for %v, want: %q got: %q", name, want, files[i].Content)
+		}
+	}
+
+	cases := []struct {
+		input string
+		part  int
+		want  string
+	}{
+		{"example1.txt", 1, "142"},
+		{"example2.txt", 2, "281"},
+		{"input.txt", 1, "54630"},
+		{"input.txt", 2, "54770"},
+	}
+	for _, tc := range cases {
+		if got, ok := answers.Lookup(tc.input, tc.part); !ok || got != tc.want {
+			t.Errorf("answer for %v part %v, want: %v got: %v", tc.input, tc.part, tc.want, got)
+		}
+	}
+}
+
+func TestExampleFilesPartOneOnly(t *testing.T) {
+	page := parseFixture(t, "2023-day05-part1.html")
+	if len(page.Parts) != 1 {
+		t.Fatalf("wrong number of parts, want: 1 got: %v", len(page.Parts))
+	}
+	if len(page.Parts[0].Examples) != 2 {
+		t.Errorf("wrong number of code blocks, want: 2 got: %v", len(page.Parts[0].Examples))
+	}
+
+	files, answers := page.ExampleFiles()
+	if len(files) != 1 || !strings.HasPrefix(files[0].Content, "seeds: 79 14 55 13\n") {
+		t.Fatalf("wrong examples: %+v", files)
+	}
+	if len(answers) != 1 {
+		t.Errorf("wrong number of answers, want: 1 got: %+v", answers)
+	}
+	if got, _ := answers.Lookup("example1.txt", 1); got != "35" {
+		t.Errorf("wrong expected answer, want: 35 got: %v", got)
+	}
+}
+
+func TestAnswersRoundTrip(t *testing.T) {
+	in := "# comment\nexample1.txt 2 281\nexample1.txt 1 142\n\ninput.txt 1 a b c\n"
+	answers, err := puzzle.ReadAnswers(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sb strings.Builder
+	if err := answers.Write(&sb); err != nil {
+		t.Fatal(err)
+	}
+	want := "example1.txt 1 142\nexample1.txt 2 281\ninput.txt 1 a b c\n"
+	if sb.String() != want {
+		t.Errorf("wrong output, want: %q got: %q", want, sb.String())
+	}
+
+	if _, err := puzzle.ReadAnswers(strings.NewReader("example1.txt x 1\n")); err == nil {
+		t.Errorf("expected error for invalid part")
+	}
+}
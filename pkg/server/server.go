@@ -0,0 +1,323 @@
+// Package server is a local HTTP JSON API for running the registered
+// solvers, so editors and notebooks can use them without go run.
+//
+//	GET  /v1/years                                list the years with solutions
+//	GET  /v1/years/{year}/days                    list the days and their parts
+//	POST /v1/years/{year}/days/{day}              run both parts on the body
+//	POST /v1/years/{year}/days/{day}/parts/{part} run one part on the body
+//
+// Runs take the input as the request body, and ?impl=name to use an
+// alternate implementation or ?log=DEBUG to capture more logs.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Options limit what a request may use.
+type Options struct {
+	// Timeout limits each run, zero is no limit.
+	Timeout time.Duration
+	// MaxConcurrent is how many parts may run at once, the number of CPUs
+	// when zero. Requests wait for a slot until their timeout.
+	MaxConcurrent int
+	// MaxInput is the largest input accepted in bytes, 10MB when zero.
+	MaxInput int64
+	// MaxLogs is how many log entries are kept for each run, 1000 when
+	// zero. Later entries are counted but dropped.
+	MaxLogs int
+}
+
+// Server handles the API.
+type Server struct {
+	opts  Options
+	slots chan struct{}
+	mux   *http.ServeMux
+	log   *zap.SugaredLogger
+}
+
+// New returns a server for the days in the solver registry. Requests are
+// logged to the logger in ctx.
+func New(ctx context.Context, opts Options) *Server {
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = runtime.NumCPU()
+	}
+	if opts.MaxInput <= 0 {
+		opts.MaxInput = 10 << 20
+	}
+	if opts.MaxLogs <= 0 {
+		opts.MaxLogs = 1000
+	}
+	s := &Server{
+		opts:  opts,
+		slots: make(chan struct{}, opts.MaxConcurrent),
+		mux:   http.NewServeMux(),
+		log:   logging.FromContext(ctx).Named("server"),
+	}
+	s.mux.HandleFunc("GET /v1/years", s.handleYears)
+	s.mux.HandleFunc("GET /v1/years/{year}/days", s.handleDays)
+	s.mux.HandleFunc("POST /v1/years/{year}/days/{day}", s.handleRun)
+	s.mux.HandleFunc("POST /v1/years/{year}/days/{day}/parts/{part}", s.handleRun)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	s.mux.ServeHTTP(w, r)
+	s.log.Debugw("request", "method", r.Method, "path", r.URL.Path, "took", time.Since(start))
+}
+
+// DayInfo describes a registered day.
+type DayInfo struct {
+	Year  int   `json:"year"`
+	Day   int   `json:"day"`
+	Parts []int `json:"parts"`
+	// Impls are the implementations of each part, by part.
+	Impls map[int][]string `json:"impls"`
+}
+
+// Log is a captured log entry.
+type Log struct {
+	Level   string         `json:"level"`
+	Logger  string         `json:"logger,omitempty"`
+	Message string         `json:"message"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+// Result is the outcome of running one part.
+type Result struct {
+	Year       int     `json:"year"`
+	Day        int     `json:"day"`
+	Part       int     `json:"part"`
+	Impl       string  `json:"impl"`
+	Answer     any     `json:"answer,omitempty"`
+	Error      string  `json:"error,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+	Logs       []Log   `json:"logs"`
+	// LogsDropped counts the entries past Options.MaxLogs.
+	LogsDropped int `json:"logs_dropped,omitempty"`
+
+	status int
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) handleYears(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]int{"years": solver.Years()})
+}
+
+func (s *Server) handleDays(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid year %q", r.PathValue("year")))
+		return
+	}
+	days := make([]DayInfo, 0)
+	for _, d := range solver.Days(year) {
+		info := DayInfo{Year: d.Year, Day: d.Day, Parts: make([]int, 0), Impls: make(map[int][]string)}
+		for _, part := range []int{1, 2} {
+			impls := d.Impls(part)
+			if d.Part(part) != nil {
+				info.Parts = append(info.Parts, part)
+			}
+			for _, impl := range impls {
+				info.Impls[part] = append(info.Impls[part], impl.Name)
+			}
+		}
+		days = append(days, info)
+	}
+	if len(days) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no solutions for %d", year))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]DayInfo{"days": days})
+}
+
+func (s *Server) handleRun(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid year %q", r.PathValue("year")))
+		return
+	}
+	day, err := strconv.Atoi(r.PathValue("day"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid day %q", r.PathValue("day")))
+		return
+	}
+	parts := solver.Parts{1, 2}
+	if p := r.PathValue("part"); p != "" {
+		parts, err = solver.ParseParts(p)
+		if err != nil || len(parts) != 1 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid part %q, want 1 or 2", p))
+			return
+		}
+	}
+	d, ok := solver.Lookup(year, day)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no solution for %d day %d", year, day))
+		return
+	}
+	impl := r.URL.Query().Get("impl")
+	if impl == "" {
+		impl = solver.DefaultImpl
+	}
+	level := zapcore.InfoLevel
+	if l := r.URL.Query().Get("log"); l != "" {
+		level, err = logging.ParseLevel(l)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.opts.MaxInput))
+	if err != nil {
+		var tooBig *http.MaxBytesError
+		if errors.As(err, &tooBig) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("input is larger than %d bytes", tooBig.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	results := make([]*Result, 0, len(parts))
+	status := http.StatusOK
+	for _, part := range parts {
+		res := s.run(r.Context(), d, part, impl, input, level)
+		results = append(results, res)
+		status = max(status, res.status)
+	}
+	if r.PathValue("part") != "" {
+		writeJSON(w, status, results[0])
+		return
+	}
+	writeJSON(w, status, map[string][]*Result{"results": results})
+}
+
+// run solves one part once a slot is free, capturing its logs. Each part
+// has its own deadline, which includes waiting for the slot, so a part that
+// timed out doesn't take the time of the next.
+func (s *Server) run(ctx context.Context, d *solver.Day, part int, name string, input []byte, level zapcore.Level) *Result {
+	res := &Result{Year: d.Year, Day: d.Day, Part: part, Impl: name, Logs: make([]Log, 0), status: http.StatusOK}
+
+	impl, ok := d.Impl(part, name)
+	if !ok {
+		res.status = http.StatusNotFound
+		res.Error = fmt.Sprintf("no %q implementation of part %d", name, part)
+		if d.Part(part) == nil {
+			res.status = http.StatusNotImplemented
+			res.Error = solver.ErrNotImplemented.Error()
+		}
+		return res
+	}
+
+	if s.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.Timeout)
+		defer cancel()
+	}
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		res.status = http.StatusGatewayTimeout
+		res.Error = fmt.Sprintf("timed out after %v waiting for a free slot, too many runs in progress", s.opts.Timeout)
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			res.Error = fmt.Sprintf("gave up waiting for a free slot: %v", ctx.Err())
+		}
+		return res
+	}
+	// A run that times out keeps going in the background, so the slot is
+	// only given back once the solver returns.
+	f := impl.Func
+	impl.Func = func(ctx context.Context, r io.Reader) (any, error) {
+		defer func() { <-s.slots }()
+		return f(ctx, r)
+	}
+
+	observed, logs := observer.New(level)
+	core := &capCore{Core: observed, max: int64(s.opts.MaxLogs), count: new(atomic.Int64)}
+	ctx = logging.WithLogger(ctx, zap.New(core).Sugar())
+	out := d.RunImpl(ctx, part, impl, solver.FromBytes(input))
+
+	res.Answer = out.Answer
+	res.DurationMS = float64(out.Duration.Microseconds()) / 1000
+	res.LogsDropped = core.dropped()
+	for _, e := range logs.AllUntimed() {
+		res.Logs = append(res.Logs, Log{
+			Level:   e.Level.CapitalString(),
+			Logger:  e.LoggerName,
+			Message: e.Message,
+			Fields:  e.ContextMap(),
+		})
+	}
+	switch {
+	case out.Err == nil:
+	case errors.Is(out.Err, context.DeadlineExceeded):
+		res.status = http.StatusGatewayTimeout
+		res.Error = fmt.Sprintf("timed out after %v", s.opts.Timeout)
+	default:
+		res.status = http.StatusUnprocessableEntity
+		res.Error = out.Err.Error()
+	}
+	return res
+}
+
+// capCore keeps the first max entries written through it and any cores
+// derived with With, and counts the rest.
+type capCore struct {
+	zapcore.Core
+	max   int64
+	count *atomic.Int64
+}
+
+func (c *capCore) With(fields []zapcore.Field) zapcore.Core {
+	return &capCore{Core: c.Core.With(fields), max: c.max, count: c.count}
+}
+
+func (c *capCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *capCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	if c.count.Add(1) > c.max {
+		return nil
+	}
+	return c.Core.Write(e, fields)
+}
+
+func (c *capCore) dropped() int {
+	return int(max(c.count.Load()-c.max, 0))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,165 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/server"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+var (
+	release = make(chan struct{})
+	hold    = make(chan struct{})
+)
+
+func init() {
+	solver.Register(&solver.Day{
+		Year: 1990,
+		Day:  1,
+		Part1: func(ctx context.Context, r io.Reader) (any, error) {
+			b, err := io.ReadAll(r)
+			logging.FromContext(ctx).Infow("read input", "bytes", len(b))
+			return len(b), err
+		},
+		Alternates: map[int][]solver.Impl{
+			1: {{Name: "slow", Func: func(ctx context.Context, r io.Reader) (any, error) {
+				<-release
+				return 0, nil
+			}}},
+		},
+	})
+	solver.Register(&solver.Day{
+		Year: 1991,
+		Day:  1,
+		Part1: func(ctx context.Context, r io.Reader) (any, error) {
+			<-hold
+			return 0, nil
+		},
+		Part2: func(ctx context.Context, r io.Reader) (any, error) {
+			return 2, nil
+		},
+	})
+	solver.Register(&solver.Day{
+		Year: 1992,
+		Day:  1,
+		Part1: func(ctx context.Context, r io.Reader) (any, error) {
+			log := logging.FromContext(ctx)
+			for i := 0; i < 10; i++ {
+				log.Debugw("step", "i", i)
+				log.Warnw("careful", "i", i)
+			}
+			return 0, nil
+		},
+	})
+}
+
+func do(t *testing.T, srv http.Handler, method, path, body string, v any) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if v != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatalf("%s %s: %v\n%s", method, path, err, rec.Body.String())
+		}
+	}
+	return rec.Code
+}
+
+func TestRun(t *testing.T) {
+	srv := server.New(context.Background(), server.Options{MaxInput: 10})
+
+	var days struct{ Days []server.DayInfo }
+	if code := do(t, srv, "GET", "/v1/years/1990/days", "", &days); code != http.StatusOK || len(days.Days) != 1 {
+		t.Fatalf("days, want: 200 and 1 day got: %v and %+v", code, days)
+	}
+	if got := days.Days[0].Impls[1]; len(got) != 2 || got[1] != "slow" {
+		t.Errorf("impls, want: [default slow] got: %v", got)
+	}
+
+	var res server.Result
+	if code := do(t, srv, "POST", "/v1/years/1990/days/1/parts/1", "hello", &res); code != http.StatusOK {
+		t.Fatalf("run, want: 200 got: %v %+v", code, res)
+	}
+	if res.Answer != float64(5) {
+		t.Errorf("answer, want: 5 got: %v", res.Answer)
+	}
+	if len(res.Logs) != 1 || res.Logs[0].Message != "read input" || res.Logs[0].Fields["day"] != float64(1) {
+		t.Errorf("logs, want: read input tagged with the day got: %+v", res.Logs)
+	}
+
+	var both struct{ Results []server.Result }
+	if code := do(t, srv, "POST", "/v1/years/1990/days/1", "hi", &both); code != http.StatusNotImplemented || len(both.Results) != 2 {
+		t.Errorf("both parts, want: 501 for part 2 got: %v %+v", code, both)
+	}
+
+	cases := map[string]int{
+		"/v1/years/1990/days/2/parts/1":              http.StatusNotFound,
+		"/v1/years/1990/days/1/parts/3":              http.StatusBadRequest,
+		"/v1/years/1990/days/1/parts/1?impl=bad":     http.StatusNotFound,
+		"/v1/years/1990/days/1/parts/1?log=loud":     http.StatusBadRequest,
+		"/v1/years/1990/days/1/parts/1?log=CRITICAL": http.StatusOK,
+	}
+	for path, want := range cases {
+		if code := do(t, srv, "POST", path, "", nil); code != want {
+			t.Errorf("%s, want: %v got: %v", path, want, code)
+		}
+	}
+	if code := do(t, srv, "POST", "/v1/years/1990/days/1/parts/1", strings.Repeat("x", 11), nil); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large input, want: 413 got: %v", code)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	srv := server.New(context.Background(), server.Options{Timeout: 20 * time.Millisecond, MaxConcurrent: 1})
+	defer close(release)
+
+	// The slow run times out but holds the only slot until it returns.
+	if code := do(t, srv, "POST", "/v1/years/1990/days/1/parts/1?impl=slow", "", nil); code != http.StatusGatewayTimeout {
+		t.Errorf("timeout, want: 504 got: %v", code)
+	}
+	var res server.Result
+	if code := do(t, srv, "POST", "/v1/years/1990/days/1/parts/1", "", &res); code != http.StatusGatewayTimeout || !strings.Contains(res.Error, "waiting for a free slot") {
+		t.Errorf("busy, want: 504 waiting for a slot got: %v %q", code, res.Error)
+	}
+}
+
+func TestPartDeadlines(t *testing.T) {
+	srv := server.New(context.Background(), server.Options{Timeout: 20 * time.Millisecond, MaxConcurrent: 2})
+	defer close(hold)
+
+	// Part 1 times out, part 2 still gets its own time to run.
+	var got struct {
+		Results []server.Result `json:"results"`
+	}
+	do(t, srv, "POST", "/v1/years/1991/days/1", "", &got)
+	if len(got.Results) != 2 {
+		t.Fatalf("want 2 results, got: %+v", got)
+	}
+	if !strings.Contains(got.Results[0].Error, "timed out") {
+		t.Errorf("part 1, want: timed out got: %q", got.Results[0].Error)
+	}
+	if got.Results[1].Error != "" || got.Results[1].Answer != float64(2) {
+		t.Errorf("part 2, want: 2 got: %v %q", got.Results[1].Answer, got.Results[1].Error)
+	}
+}
+
+func TestLogs(t *testing.T) {
+	srv := server.New(context.Background(), server.Options{MaxLogs: 3})
+
+	var res server.Result
+	if code := do(t, srv, "POST", "/v1/years/1992/days/1/parts/1?log=warning", "", &res); code != http.StatusOK {
+		t.Fatalf("run, want: 200 got: %v %+v", code, res)
+	}
+	if len(res.Logs) != 3 || res.Logs[0].Level != "WARN" || res.LogsDropped != 7 {
+		t.Errorf("logs, want: 3 warnings and 7 dropped got: %+v and %v", res.Logs, res.LogsDropped)
+	}
+}
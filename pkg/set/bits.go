@@ -0,0 +1,144 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+	"strings"
+)
+
+// Bits is a set of small non-negative integers, one bit each. It uses far
+// less memory than a Set[int] and the algebra works a word at a time, so
+// it suits dense domains like ids numbered from 1 or grid cells numbered
+// row*width+col. The zero value is an empty set ready to use.
+type Bits struct {
+	words []uint64
+}
+
+// NewBits returns a set holding the values.
+func NewBits(vs ...int) Bits {
+	var b Bits
+	b.Add(vs...)
+	return b
+}
+
+// Add puts the values in the set, it panics on a negative value.
+func (b *Bits) Add(vs ...int) {
+	for _, v := range vs {
+		if v < 0 {
+			panic(fmt.Sprintf("set: negative value %d in Bits", v))
+		}
+		w := v / 64
+		for len(b.words) <= w {
+			b.words = append(b.words, 0)
+		}
+		b.words[w] |= 1 << (v % 64)
+	}
+}
+
+// Remove takes the values out of the set.
+func (b *Bits) Remove(vs ...int) {
+	for _, v := range vs {
+		if w := v / 64; v >= 0 && w < len(b.words) {
+			b.words[w] &^= 1 << (v % 64)
+		}
+	}
+}
+
+// Contains reports whether the value is in the set.
+func (b Bits) Contains(v int) bool {
+	w := v / 64
+	return v >= 0 && w < len(b.words) && b.words[w]&(1<<(v%64)) != 0
+}
+
+// Len is the number of values in the set.
+func (b Bits) Len() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Clone returns a copy of the set.
+func (b Bits) Clone() Bits {
+	return Bits{words: append([]uint64(nil), b.words...)}
+}
+
+// word is the i'th word, zero past the end.
+func (b Bits) word(i int) uint64 {
+	if i < len(b.words) {
+		return b.words[i]
+	}
+	return 0
+}
+
+// Union returns the values in either set.
+func (b Bits) Union(o Bits) Bits {
+	out := Bits{words: make([]uint64, max(len(b.words), len(o.words)))}
+	for i := range out.words {
+		out.words[i] = b.word(i) | o.word(i)
+	}
+	return out
+}
+
+// Intersect returns the values in both sets.
+func (b Bits) Intersect(o Bits) Bits {
+	out := Bits{words: make([]uint64, min(len(b.words), len(o.words)))}
+	for i := range out.words {
+		out.words[i] = b.words[i] & o.words[i]
+	}
+	return out
+}
+
+// Difference returns the values of b that are not in o.
+func (b Bits) Difference(o Bits) Bits {
+	out := Bits{words: make([]uint64, len(b.words))}
+	for i := range out.words {
+		out.words[i] = b.words[i] &^ o.word(i)
+	}
+	return out
+}
+
+// SubsetOf reports whether every value of b is also in o.
+func (b Bits) SubsetOf(o Bits) bool {
+	for i, w := range b.words {
+		if w&^o.word(i) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether the sets hold the same values.
+func (b Bits) Equal(o Bits) bool {
+	for i := range max(len(b.words), len(o.words)) {
+		if b.word(i) != o.word(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// All iterates over the values in ascending order.
+func (b Bits) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b.words {
+			for w != 0 {
+				bit := bits.TrailingZeros64(w)
+				if !yield(i*64 + bit) {
+					return
+				}
+				w &^= 1 << bit
+			}
+		}
+	}
+}
+
+func (b Bits) String() string {
+	parts := make([]string, 0, b.Len())
+	for v := range b.All() {
+		parts = append(parts, fmt.Sprint(v))
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
@@ -0,0 +1,129 @@
+// Package set has sets with the usual set algebra, a map backed Set for any
+// comparable value and Bits for small non-negative integers.
+package set
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Set is a set of values. Like a map, the zero value can be read but needs
+// New or make before adding. The algebra methods return new sets and leave
+// their receiver alone.
+type Set[T comparable] map[T]struct{}
+
+// New returns a set holding the values.
+func New[T comparable](vs ...T) Set[T] {
+	s := make(Set[T], len(vs))
+	s.Add(vs...)
+	return s
+}
+
+// Of returns a set holding the values of a sequence.
+func Of[T comparable](seq iter.Seq[T]) Set[T] {
+	s := make(Set[T])
+	for v := range seq {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
+// Add puts the values in the set.
+func (s Set[T]) Add(vs ...T) {
+	for _, v := range vs {
+		s[v] = struct{}{}
+	}
+}
+
+// Remove takes the values out of the set.
+func (s Set[T]) Remove(vs ...T) {
+	for _, v := range vs {
+		delete(s, v)
+	}
+}
+
+// Contains reports whether the value is in the set.
+func (s Set[T]) Contains(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// Len is the number of values in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Clone returns a copy of the set.
+func (s Set[T]) Clone() Set[T] {
+	out := make(Set[T], len(s))
+	maps.Copy(out, s)
+	return out
+}
+
+// Union returns the values in either set.
+func (s Set[T]) Union(o Set[T]) Set[T] {
+	out := s.Clone()
+	maps.Copy(out, o)
+	return out
+}
+
+// Intersect returns the values in both sets.
+func (s Set[T]) Intersect(o Set[T]) Set[T] {
+	if len(o) < len(s) {
+		s, o = o, s
+	}
+	out := make(Set[T])
+	for v := range s {
+		if o.Contains(v) {
+			out[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// Difference returns the values of s that are not in o.
+func (s Set[T]) Difference(o Set[T]) Set[T] {
+	out := make(Set[T])
+	for v := range s {
+		if !o.Contains(v) {
+			out[v] = struct{}{}
+		}
+	}
+	return out
+}
+
+// SubsetOf reports whether every value of s is also in o.
+func (s Set[T]) SubsetOf(o Set[T]) bool {
+	if len(s) > len(o) {
+		return false
+	}
+	for v := range s {
+		if !o.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether the sets hold the same values.
+func (s Set[T]) Equal(o Set[T]) bool {
+	return len(s) == len(o) && s.SubsetOf(o)
+}
+
+// All iterates over the values in no particular order, use Sorted or
+// SortedFunc when the order matters.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// SortedFunc iterates over the values in the order given by cmp.
+func (s Set[T]) SortedFunc(cmp func(a, b T) int) iter.Seq[T] {
+	return slices.Values(slices.SortedFunc(s.All(), cmp))
+}
+
+// Sorted iterates over the values in ascending order.
+func Sorted[T cmp.Ordered](s Set[T]) iter.Seq[T] {
+	return slices.Values(slices.Sorted(s.All()))
+}
@@ -0,0 +1,89 @@
+package set_test
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+	"testing/quick"
+
+	"github.com/mikehelmick/adventofcode/pkg/set"
+)
+
+func TestSet(t *testing.T) {
+	a := set.New("a", "b", "c")
+	b := set.New("b", "c", "d")
+
+	cases := []struct {
+		name string
+		got  set.Set[string]
+		want []string
+	}{
+		{name: "Union", got: a.Union(b), want: []string{"a", "b", "c", "d"}},
+		{name: "Intersect", got: a.Intersect(b), want: []string{"b", "c"}},
+		{name: "Difference", got: a.Difference(b), want: []string{"a"}},
+		{name: "receiver", got: a, want: []string{"a", "b", "c"}},
+	}
+	for _, tc := range cases {
+		if got := slices.Collect(set.Sorted(tc.got)); !slices.Equal(got, tc.want) {
+			t.Errorf("%s, want: %v got: %v", tc.name, tc.want, got)
+		}
+	}
+
+	if !a.Intersect(b).SubsetOf(a) || a.SubsetOf(b) {
+		t.Errorf("SubsetOf, want: intersection in a and a not in b")
+	}
+	if !a.Equal(set.New("c", "b", "a")) || a.Equal(b) {
+		t.Errorf("Equal, want: only the same values to be equal")
+	}
+
+	desc := slices.Collect(a.SortedFunc(func(x, y string) int { return cmp.Compare(y, x) }))
+	if want := []string{"c", "b", "a"}; !slices.Equal(desc, want) {
+		t.Errorf("SortedFunc, want: %v got: %v", want, desc)
+	}
+}
+
+// small keeps generated values in a range where they collide often.
+func small(vs []uint8) []int {
+	out := make([]int, len(vs))
+	for i, v := range vs {
+		out[i] = int(v % 150)
+	}
+	return out
+}
+
+// TestBitsMatchesSet checks Bits against Set, the obviously correct version.
+func TestBitsMatchesSet(t *testing.T) {
+	same := func(b set.Bits, s set.Set[int]) bool {
+		return slices.Equal(slices.Collect(b.All()), slices.Collect(set.Sorted(s))) && b.Len() == s.Len()
+	}
+	f := func(x, y []uint8) bool {
+		xs, ys := small(x), small(y)
+		bx, by := set.NewBits(xs...), set.NewBits(ys...)
+		sx, sy := set.New(xs...), set.New(ys...)
+		return same(bx.Union(by), sx.Union(sy)) &&
+			same(bx.Intersect(by), sx.Intersect(sy)) &&
+			same(bx.Difference(by), sx.Difference(sy)) &&
+			bx.SubsetOf(by) == sx.SubsetOf(sy) &&
+			bx.Equal(by) == sx.Equal(sy) &&
+			bx.Intersect(by).Equal(by.Intersect(bx))
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 500}); err != nil {
+		t.Errorf("Bits and Set disagree: %v", err)
+	}
+}
+
+func TestBits(t *testing.T) {
+	var b set.Bits
+	b.Add(3, 130, 64, 3)
+	b.Remove(64, 500)
+
+	if got, want := b.String(), "{3 130}"; got != want {
+		t.Errorf("String, want: %v got: %v", want, got)
+	}
+	if !b.Contains(130) || b.Contains(64) || b.Contains(-1) {
+		t.Errorf("Contains, want: only 3 and 130 got: %v", b)
+	}
+	if !b.Equal(set.NewBits(3, 130)) {
+		t.Errorf("Equal, want: trailing empty words ignored")
+	}
+}
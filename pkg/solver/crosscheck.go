@@ -0,0 +1,51 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+)
+
+// Check is the result of running every implementation of a part over the
+// same input.
+type Check struct {
+	Part    int
+	Results []Result
+}
+
+// CrossCheck runs each implementation of the part in turn.
+func (d *Day) CrossCheck(ctx context.Context, part int, input Opener) *Check {
+	c := &Check{Part: part}
+	for _, impl := range d.Impls(part) {
+		c.Results = append(c.Results, d.RunImpl(ctx, part, impl, input))
+	}
+	return c
+}
+
+// Agree reports whether every implementation succeeded with the same answer.
+// Answers are compared by how they print so that an int and an int64 with
+// the same value agree.
+func (c *Check) Agree() bool {
+	if len(c.Results) == 0 {
+		return true
+	}
+	for _, res := range c.Results {
+		if res.Err != nil {
+			return false
+		}
+	}
+	for _, res := range c.Results[1:] {
+		if fmt.Sprint(res.Answer) != fmt.Sprint(c.Results[0].Answer) {
+			return false
+		}
+	}
+	return true
+}
+
+// Differs reports whether the result disagrees with the first one, which is
+// the default implementation when the part is solved.
+func (c *Check) Differs(i int) bool {
+	if c.Results[i].Err != nil {
+		return true
+	}
+	return i > 0 && (c.Results[0].Err != nil || fmt.Sprint(c.Results[i].Answer) != fmt.Sprint(c.Results[0].Answer))
+}
@@ -0,0 +1,245 @@
+// Package solver defines how a day's solution is exposed so that each part
+// can be run on its own, and keeps a registry of all the days.
+package solver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+)
+
+// ErrNotImplemented is reported for parts that don't have a solution.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Func solves one part of a puzzle, reading the puzzle input from r.
+type Func func(ctx context.Context, r io.Reader) (any, error)
+
+// DefaultImpl is the name of the implementation in Part1 or Part2.
+const DefaultImpl = "default"
+
+// Impl is a named implementation of one part, like "bruteforce" or "fast".
+type Impl struct {
+	Name string
+	Func Func
+}
+
+// Day is the solution to one day's puzzle. Either part may be nil if it
+// hasn't been solved.
+type Day struct {
+	Year  int
+	Day   int
+	Part1 Func
+	Part2 Func
+	// Alternates are other implementations of each part, keyed by part.
+	// They are used to cross check the answers of Part1 and Part2.
+	Alternates map[int][]Impl
+}
+
+func (d *Day) String() string {
+	return fmt.Sprintf("%d day %02d", d.Year, d.Day)
+}
+
+// Part returns the function for part 1 or 2.
+func (d *Day) Part(part int) Func {
+	switch part {
+	case 1:
+		return d.Part1
+	case 2:
+		return d.Part2
+	}
+	return nil
+}
+
+// Impls returns every implementation of the part, starting with the
+// default one if the part is solved.
+func (d *Day) Impls(part int) []Impl {
+	impls := make([]Impl, 0, 1+len(d.Alternates[part]))
+	if f := d.Part(part); f != nil {
+		impls = append(impls, Impl{Name: DefaultImpl, Func: f})
+	}
+	return append(impls, d.Alternates[part]...)
+}
+
+// Impl returns the named implementation of the part.
+func (d *Day) Impl(part int, name string) (Impl, bool) {
+	for _, impl := range d.Impls(part) {
+		if impl.Name == name {
+			return impl, true
+		}
+	}
+	return Impl{}, false
+}
+
+// Opener returns a new reader over the puzzle input for each run, so every
+// part and implementation reads the input from the start. Readers that are
+// also io.Closers are closed when the run ends. A nil Opener is an empty
+// input.
+type Opener func() (io.Reader, error)
+
+// FromBytes opens input that is already in memory. Each run reads its own
+// bytes.Buffer over b without copying it, and parse.Bytes hands the
+// solution b itself.
+func FromBytes(b []byte) Opener {
+	return func() (io.Reader, error) {
+		return bytes.NewBuffer(b), nil
+	}
+}
+
+// FromFile opens the file for each run, so solutions that scan their input
+// stream it instead of holding all of it.
+func FromFile(path string) Opener {
+	return func() (io.Reader, error) {
+		return os.Open(path)
+	}
+}
+
+// Result is the outcome of running one part.
+type Result struct {
+	Part     int
+	Impl     string
+	Answer   any
+	Err      error
+	Duration time.Duration
+}
+
+// Run solves one part over the input with the default implementation.
+func (d *Day) Run(ctx context.Context, part int, input Opener) Result {
+	f := d.Part(part)
+	if f == nil {
+		return Result{Part: part, Impl: DefaultImpl, Err: ErrNotImplemented}
+	}
+	return d.RunImpl(ctx, part, Impl{Name: DefaultImpl, Func: f}, input)
+}
+
+// RunImpl solves one part over the input with the given implementation.
+// Panics in the solution are returned as errors so a failure in one part
+// doesn't hide the other. The solution's logger from logging.FromContext is
+// tagged with the year, day and part, and the implementation if it isn't
+// the default. If ctx is done before the solution returns,
+// RunImpl returns the context's error, though the solution keeps running
+// in the background unless it checks ctx itself.
+func (d *Day) RunImpl(ctx context.Context, part int, impl Impl, input Opener) Result {
+	start := time.Now()
+	done := make(chan Result, 1)
+	go func() {
+		done <- run(d.withLogger(ctx, part, impl.Name), impl, part, input)
+	}()
+	select {
+	case res := <-done:
+		return res
+	case <-ctx.Done():
+		return Result{Part: part, Impl: impl.Name, Err: ctx.Err(), Duration: time.Since(start)}
+	}
+}
+
+// withLogger tags the context's logger for one run.
+func (d *Day) withLogger(ctx context.Context, part int, impl string) context.Context {
+	logger := logging.FromContext(ctx).With("year", d.Year, "day", d.Day, "part", part)
+	if impl != DefaultImpl {
+		logger = logger.With("impl", impl)
+	}
+	return logging.WithLogger(ctx, logger)
+}
+
+func run(ctx context.Context, impl Impl, part int, input Opener) (res Result) {
+	res.Part = part
+	res.Impl = impl.Name
+	start := time.Now()
+	defer func() {
+		res.Duration = time.Since(start)
+		if r := recover(); r != nil {
+			res.Err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	if input == nil {
+		input = FromBytes(nil)
+	}
+	r, err := input()
+	if err != nil {
+		res.Err = fmt.Errorf("opening input: %w", err)
+		return res
+	}
+	if c, ok := r.(io.Closer); ok {
+		defer c.Close()
+	}
+	res.Answer, res.Err = impl.Func(ctx, r)
+	return res
+}
+
+// Parts is a selection of parts to run.
+type Parts []int
+
+// ParseParts parses "1", "2" or "both".
+func ParseParts(s string) (Parts, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1":
+		return Parts{1}, nil
+	case "2":
+		return Parts{2}, nil
+	case "both", "":
+		return Parts{1, 2}, nil
+	}
+	return nil, fmt.Errorf("invalid part %q, want 1, 2 or both", s)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[int]map[int]*Day)
+)
+
+// Register adds a day to the registry. It is meant to be called from the
+// init function of the day's package and panics on duplicates.
+func Register(d *Day) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if registry[d.Year] == nil {
+		registry[d.Year] = make(map[int]*Day)
+	}
+	if _, ok := registry[d.Year][d.Day]; ok {
+		panic(fmt.Sprintf("solver: %v registered twice", d))
+	}
+	registry[d.Year][d.Day] = d
+}
+
+// Lookup returns the registered day.
+func Lookup(year, day int) (*Day, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	d, ok := registry[year][day]
+	return d, ok
+}
+
+// Years returns the years that have registered days, in order.
+func Years() []int {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	years := make([]int, 0, len(registry))
+	for y := range registry {
+		years = append(years, y)
+	}
+	sort.Ints(years)
+	return years
+}
+
+// Days returns the registered days for the year, in order.
+func Days(year int) []*Day {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	days := make([]*Day, 0, len(registry[year]))
+	for _, d := range registry[year] {
+		days = append(days, d)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Day < days[j].Day })
+	return days
+}
@@ -0,0 +1,216 @@
+package solver_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mikehelmick/adventofcode/pkg/logging"
+	"github.com/mikehelmick/adventofcode/pkg/logging/logtest"
+	"github.com/mikehelmick/adventofcode/pkg/parse"
+	"github.com/mikehelmick/adventofcode/pkg/solver"
+)
+
+func TestRun(t *testing.T) {
+	d := &solver.Day{
+		Year: 1999,
+		Day:  1,
+		Part1: func(ctx context.Context, r io.Reader) (any, error) {
+			b, err := io.ReadAll(r)
+			return len(b), err
+		},
+	}
+
+	if res := d.Run(context.Background(), 1, solver.FromBytes([]byte("hello"))); res.Err != nil || res.Answer != 5 {
+		t.Errorf("part 1, want: 5 got: %v, %v", res.Answer, res.Err)
+	}
+	if res := d.Run(context.Background(), 2, nil); !errors.Is(res.Err, solver.ErrNotImplemented) {
+		t.Errorf("part 2, want: %v got: %v", solver.ErrNotImplemented, res.Err)
+	}
+
+	d.Part2 = func(ctx context.Context, r io.Reader) (any, error) {
+		panic("oops")
+	}
+	if res := d.Run(context.Background(), 2, nil); res.Err == nil || !strings.Contains(res.Err.Error(), "oops") {
+		t.Errorf("panic should be an error, got: %v", res.Err)
+	}
+
+	d.Part2 = func(ctx context.Context, r io.Reader) (any, error) {
+		time.Sleep(time.Second)
+		return 1, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if res := d.Run(ctx, 2, nil); !errors.Is(res.Err, context.DeadlineExceeded) {
+		t.Errorf("timeout, want: %v got: %v", context.DeadlineExceeded, res.Err)
+	}
+}
+
+func TestRunFromBytes(t *testing.T) {
+	// Every run reads the shared input, and parse.Bytes hands it over
+	// without a copy.
+	input := []byte("hello")
+	same := func(ctx context.Context, r io.Reader) (any, error) {
+		b, err := parse.Bytes(r)
+		if err != nil {
+			return nil, err
+		}
+		return len(b) == len(input) && &b[0] == &input[0], nil
+	}
+	d := &solver.Day{Year: 1999, Day: 2, Part1: same, Part2: same}
+
+	for _, part := range []int{1, 2, 1} {
+		if res := d.Run(context.Background(), part, solver.FromBytes(input)); res.Err != nil || res.Answer != true {
+			t.Errorf("part %d, want: the input without a copy got: %v, %v", part, res.Answer, res.Err)
+		}
+	}
+}
+
+func TestRunFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var opened *os.File
+	d := &solver.Day{Year: 1999, Day: 3, Part1: func(ctx context.Context, r io.Reader) (any, error) {
+		opened, _ = r.(*os.File)
+		b, err := io.ReadAll(r)
+		return string(b), err
+	}}
+
+	if res := d.Run(context.Background(), 1, solver.FromFile(path)); res.Err != nil || res.Answer != "hello" {
+		t.Errorf("want: hello got: %v, %v", res.Answer, res.Err)
+	}
+	if opened == nil {
+		t.Fatalf("want the file itself as the reader")
+	}
+	if err := opened.Close(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("file was not closed after the run, got: %v", err)
+	}
+	if res := d.Run(context.Background(), 1, solver.FromFile(path+".missing")); res.Err == nil {
+		t.Errorf("want an error for a missing file")
+	}
+}
+
+func TestRunLogger(t *testing.T) {
+	logged := func(ctx context.Context, r io.Reader) (any, error) {
+		logging.FromContext(ctx).Infow("solving")
+		return 0, nil
+	}
+	d := &solver.Day{
+		Year:       1996,
+		Day:        3,
+		Part2:      logged,
+		Alternates: map[int][]solver.Impl{2: {{Name: "fast", Func: logged}}},
+	}
+
+	ctx, logs := logtest.WithLogs(context.Background())
+	d.CrossCheck(ctx, 2, nil)
+
+	entries := logs.Message("solving")
+	if len(entries) != 2 {
+		t.Fatalf("solving entries, want: 2 got: %v", len(entries))
+	}
+	for _, e := range entries {
+		for key, want := range map[string]any{"year": int64(1996), "day": int64(3), "part": int64(2)} {
+			if got, _ := logtest.Field(e, key); got != want {
+				t.Errorf("field %s, want: %v got: %v", key, want, got)
+			}
+		}
+	}
+	if _, ok := logtest.Field(entries[0], "impl"); ok {
+		t.Errorf("default implementation should not be tagged")
+	}
+	if got, _ := logtest.Field(entries[1], "impl"); got != "fast" {
+		t.Errorf("field impl, want: fast got: %v", got)
+	}
+}
+
+func TestParseParts(t *testing.T) {
+	cases := map[string]solver.Parts{
+		"1":    {1},
+		"2":    {2},
+		"both": {1, 2},
+		"":     {1, 2},
+	}
+	for in, want := range cases {
+		got, err := solver.ParseParts(in)
+		if err != nil {
+			t.Fatalf("ParseParts(%q): %v", in, err)
+		}
+		if len(got) != len(want) || got[0] != want[0] {
+			t.Errorf("ParseParts(%q), want: %v got: %v", in, want, got)
+		}
+	}
+	if _, err := solver.ParseParts("3"); err == nil {
+		t.Errorf("expected error for part 3")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	solver.Register(&solver.Day{Year: 1998, Day: 2})
+	solver.Register(&solver.Day{Year: 1998, Day: 1})
+
+	if _, ok := solver.Lookup(1998, 1); !ok {
+		t.Errorf("day 1 not registered")
+	}
+	if _, ok := solver.Lookup(1998, 3); ok {
+		t.Errorf("day 3 should not be registered")
+	}
+	days := solver.Days(1998)
+	if len(days) != 2 || days[0].Day != 1 || days[1].Day != 2 {
+		t.Errorf("days not in order: %v", days)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic on duplicate registration")
+		}
+	}()
+	solver.Register(&solver.Day{Year: 1998, Day: 1})
+}
+
+func TestCrossCheck(t *testing.T) {
+	answer := func(v any) solver.Func {
+		return func(ctx context.Context, r io.Reader) (any, error) {
+			return v, nil
+		}
+	}
+	d := &solver.Day{
+		Year:  1997,
+		Day:   1,
+		Part1: answer(5),
+		Part2: answer(7),
+		Alternates: map[int][]solver.Impl{
+			1: {{Name: "wide", Func: answer(int64(5))}},
+			2: {{Name: "good", Func: answer(7)}, {Name: "bad", Func: answer(8)}},
+		},
+	}
+
+	c := d.CrossCheck(context.Background(), 1, nil)
+	if len(c.Results) != 2 || c.Results[0].Impl != solver.DefaultImpl || c.Results[1].Impl != "wide" {
+		t.Fatalf("wrong results: %+v", c.Results)
+	}
+	if !c.Agree() {
+		t.Errorf("part 1 should agree: %+v", c.Results)
+	}
+
+	c = d.CrossCheck(context.Background(), 2, nil)
+	if c.Agree() {
+		t.Errorf("part 2 should disagree: %+v", c.Results)
+	}
+	for i, want := range []bool{false, false, true} {
+		if got := c.Differs(i); got != want {
+			t.Errorf("Differs(%d), want: %v got: %v", i, want, got)
+		}
+	}
+
+	if _, ok := d.Impl(2, "bad"); !ok {
+		t.Errorf("bad implementation not found")
+	}
+}
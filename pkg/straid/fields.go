@@ -5,11 +5,15 @@ import (
 	"strings"
 )
 
+// Field returns the field at pos after splitting s on sep. It panics if
+// there are too few fields, pkg/parse handles puzzle input with errors.
 func Field(s string, sep string, pos int) string {
 	parts := strings.Split(s, sep)
 	return parts[pos]
 }
 
+// AsInt32 converts s to an int, panicking if it isn't one. It's meant for
+// strings already known to be numbers, pkg/parse handles puzzle input.
 func AsInt32(s string) int {
 	i, err := strconv.Atoi(s)
 	if err != nil {
@@ -18,6 +22,7 @@ func AsInt32(s string) int {
 	return i
 }
 
+// AsInt converts s to an int64, panicking if it isn't one.
 func AsInt(s string) int64 {
 	i, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
@@ -0,0 +1,231 @@
+package threed
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Box is a solid block of voxels from Min to Max, inclusive, like a brick
+// from the input. A voxel at x covers x to x+1 in the mesh.
+type Box struct {
+	Min   Pos
+	Max   Pos
+	ID    int
+	Color color.Color
+}
+
+// Model is a set of boxes that is exported as a mesh for viewing in any
+// mesh viewer.
+type Model struct {
+	// Title names the model, it is added to the file name when a day
+	// exports more than one.
+	Title string
+	Boxes []Box
+}
+
+func NewModel() *Model {
+	return &Model{}
+}
+
+// AddBox adds the box between two corners in any order. Boxes with the
+// same ID are grouped together in the OBJ file.
+func (m *Model) AddBox(a, b *Pos, id int, col color.Color) {
+	m.Boxes = append(m.Boxes, Box{
+		Min:   Pos{X: min(a.X, b.X), Y: min(a.Y, b.Y), Z: min(a.Z, b.Z)},
+		Max:   Pos{X: max(a.X, b.X), Y: max(a.Y, b.Y), Z: max(a.Z, b.Z)},
+		ID:    id,
+		Color: col,
+	})
+}
+
+// AddVoxel adds a single voxel. Faces between voxels with the same ID are
+// left out of the mesh, so a shape made of voxels exports as its surface.
+func (m *Model) AddVoxel(p *Pos, id int, col color.Color) {
+	m.AddBox(p, p, id, col)
+}
+
+// face is a quad, the corners are counter clockwise seen from outside.
+type face struct {
+	corners [4]Pos
+	id      int
+	color   color.Color
+}
+
+// faces for a unit cube at the origin, with the direction they face.
+var cubeFaces = []struct {
+	normal  Pos
+	corners [4]Pos
+}{
+	{Pos{-1, 0, 0}, [4]Pos{{0, 0, 0}, {0, 0, 1}, {0, 1, 1}, {0, 1, 0}}},
+	{Pos{1, 0, 0}, [4]Pos{{1, 0, 0}, {1, 1, 0}, {1, 1, 1}, {1, 0, 1}}},
+	{Pos{0, -1, 0}, [4]Pos{{0, 0, 0}, {1, 0, 0}, {1, 0, 1}, {0, 0, 1}}},
+	{Pos{0, 1, 0}, [4]Pos{{0, 1, 0}, {0, 1, 1}, {1, 1, 1}, {1, 1, 0}}},
+	{Pos{0, 0, -1}, [4]Pos{{0, 0, 0}, {0, 1, 0}, {1, 1, 0}, {1, 0, 0}}},
+	{Pos{0, 0, 1}, [4]Pos{{0, 0, 1}, {1, 0, 1}, {1, 1, 1}, {0, 1, 1}}},
+}
+
+// faces builds the quads of every box, scaling the unit cube to the box.
+func (m *Model) faces() []face {
+	voxels := make(map[Pos]int)
+	for _, b := range m.Boxes {
+		if b.Min == b.Max {
+			voxels[b.Min] = b.ID
+		}
+	}
+
+	faces := make([]face, 0, 6*len(m.Boxes))
+	for _, b := range m.Boxes {
+		size := Pos{X: b.Max.X - b.Min.X + 1, Y: b.Max.Y - b.Min.Y + 1, Z: b.Max.Z - b.Min.Z + 1}
+		col := b.Color
+		if col == nil {
+			col = color.Gray{0xc0}
+		}
+		for _, cf := range cubeFaces {
+			if b.Min == b.Max {
+				n := b.Min
+				n.Add(&cf.normal)
+				if id, ok := voxels[n]; ok && id == b.ID {
+					continue
+				}
+			}
+			f := face{id: b.ID, color: col}
+			for i, c := range cf.corners {
+				f.corners[i] = Pos{X: b.Min.X + c.X*size.X, Y: b.Min.Y + c.Y*size.Y, Z: b.Min.Z + c.Z*size.Z}
+			}
+			faces = append(faces, f)
+		}
+	}
+	return faces
+}
+
+// WriteOBJ writes the model as a Wavefront OBJ with a group per ID. Colors
+// are written after each vertex, which most viewers understand.
+func (m *Model) WriteOBJ(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# %d boxes\n", len(m.Boxes))
+	group := 0
+	for i, f := range m.faces() {
+		if i == 0 || f.id != group {
+			group = f.id
+			fmt.Fprintf(bw, "g box%d\n", f.id)
+		}
+		r, g, b := rgb(f.color)
+		for _, c := range f.corners {
+			fmt.Fprintf(bw, "v %d %d %d %.3f %.3f %.3f\n", c.X, c.Y, c.Z, float64(r)/255, float64(g)/255, float64(b)/255)
+		}
+		// Vertices are numbered from 1, and negative numbers count back
+		// from the last one.
+		fmt.Fprintln(bw, "f -4 -3 -2 -1")
+	}
+	return bw.Flush()
+}
+
+// WritePLY writes the model as an ASCII PLY with vertex colors.
+func (m *Model) WritePLY(w io.Writer) error {
+	faces := m.faces()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "ply")
+	fmt.Fprintln(bw, "format ascii 1.0")
+	fmt.Fprintf(bw, "element vertex %d\n", 4*len(faces))
+	fmt.Fprintln(bw, "property int x\nproperty int y\nproperty int z")
+	fmt.Fprintln(bw, "property uchar red\nproperty uchar green\nproperty uchar blue")
+	fmt.Fprintf(bw, "element face %d\n", len(faces))
+	fmt.Fprintln(bw, "property list uchar int vertex_indices")
+	fmt.Fprintln(bw, "end_header")
+	for _, f := range faces {
+		r, g, b := rgb(f.color)
+		for _, c := range f.corners {
+			fmt.Fprintf(bw, "%d %d %d %d %d %d\n", c.X, c.Y, c.Z, r, g, b)
+		}
+	}
+	for i := range faces {
+		fmt.Fprintf(bw, "4 %d %d %d %d\n", 4*i, 4*i+1, 4*i+2, 4*i+3)
+	}
+	return bw.Flush()
+}
+
+func rgb(c color.Color) (uint8, uint8, uint8) {
+	r, g, b, _ := c.RGBA()
+	return uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)
+}
+
+// Save writes the model to a .obj or .ply file.
+func (m *Model) Save(path string) error {
+	var write func(io.Writer) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".obj":
+		write = m.WriteOBJ
+	case ".ply":
+		write = m.WritePLY
+	default:
+		return fmt.Errorf("%s: unknown model format, want .obj or .ply", path)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type contextKey string
+
+const exporterKey = contextKey("exporter")
+
+// Exporter collects the models that solvers build of their puzzles.
+// Solvers get it with ExporterFromContext, which is nil and keeps nothing
+// unless an export was asked for.
+type Exporter struct {
+	mu     sync.Mutex
+	models []*Model
+}
+
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+// WithExporter returns a context that carries the exporter.
+func WithExporter(ctx context.Context, e *Exporter) context.Context {
+	return context.WithValue(ctx, exporterKey, e)
+}
+
+// ExporterFromContext returns the exporter in the context, or nil.
+func ExporterFromContext(ctx context.Context) *Exporter {
+	e, _ := ctx.Value(exporterKey).(*Exporter)
+	return e
+}
+
+// Enabled is false for a nil exporter, so solvers can skip building models.
+func (e *Exporter) Enabled() bool {
+	return e != nil
+}
+
+// Add keeps a model to be saved.
+func (e *Exporter) Add(m *Model) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.models = append(e.models, m)
+}
+
+// Models returns the models added so far.
+func (e *Exporter) Models() []*Model {
+	if e == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.models
+}
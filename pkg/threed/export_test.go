@@ -0,0 +1,47 @@
+package threed_test
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/threed"
+)
+
+func TestModel(t *testing.T) {
+	cases := []struct {
+		name  string
+		ids   [2]int
+		faces int
+	}{
+		{"same id shares a face", [2]int{1, 1}, 10},
+		{"different ids", [2]int{1, 2}, 12},
+	}
+	for _, tc := range cases {
+		m := threed.NewModel()
+		m.AddVoxel(threed.NewPos(0, 0, 0), tc.ids[0], color.White)
+		m.AddVoxel(threed.NewPos(1, 0, 0), tc.ids[1], color.White)
+
+		var buf bytes.Buffer
+		if err := m.WriteOBJ(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Count(buf.String(), "\nf "); got != tc.faces {
+			t.Errorf("%s: faces, want: %v got: %v", tc.name, tc.faces, got)
+		}
+	}
+
+	m := threed.NewModel()
+	m.AddBox(threed.NewPos(2, 0, 5), threed.NewPos(0, 0, 1), 1, color.RGBA{0xff, 0x80, 0, 0xff})
+	var buf bytes.Buffer
+	if err := m.WritePLY(&buf); err != nil {
+		t.Fatal(err)
+	}
+	ply := buf.String()
+	for _, want := range []string{"element vertex 24\n", "element face 6\n", "3 1 6 255 128 0\n", "4 20 21 22 23\n"} {
+		if !strings.Contains(ply, want) {
+			t.Errorf("ply missing %q:\n%s", want, ply)
+		}
+	}
+}
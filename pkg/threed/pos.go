@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-
-	"github.com/mikehelmick/go-functional/slice"
 )
 
 var (
@@ -25,17 +23,21 @@ type Pos struct {
 	Z int
 }
 
-func ParsePos(s string) *Pos {
+// ParsePos parses a position written as "x,y,z".
+func ParsePos(s string) (*Pos, error) {
 	parts := strings.Split(s, ",")
-	ints := slice.Map(parts,
-		func(s string) int {
-			i, err := strconv.Atoi(s)
-			if err != nil {
-				panic(err)
-			}
-			return i
-		})
-	return NewPos(ints[0], ints[1], ints[2])
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid position %q, want x,y,z", s)
+	}
+	ints := make([]int, len(parts))
+	for i, part := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid position %q: %w", s, err)
+		}
+		ints[i] = v
+	}
+	return NewPos(ints[0], ints[1], ints[2]), nil
 }
 
 func NewPos(x, y, z int) *Pos {
@@ -2,9 +2,8 @@ package twod
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
-
-	"github.com/mikehelmick/adventofcode/pkg/straid"
 )
 
 const (
@@ -69,14 +68,23 @@ func NewPos(r, c int) *Pos {
 	}
 }
 
-func FromString(s string) *Pos {
-	s = strings.ReplaceAll(s, "{", "")
-	s = strings.ReplaceAll(s, "}", "")
-	parts := strings.Split(s, ",")
-
-	r := int(straid.AsInt(parts[0]))
-	c := int(straid.AsInt(parts[1]))
-	return NewPos(r, c)
+// FromString parses a position written as "{row,col}", the format of
+// String.
+func FromString(s string) (*Pos, error) {
+	t := strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+	parts := strings.Split(t, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid position %q, want {row,col}", s)
+	}
+	r, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid position %q: %w", s, err)
+	}
+	c, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid position %q: %w", s, err)
+	}
+	return NewPos(r, c), nil
 }
 
 func (p *Pos) String() string {
@@ -0,0 +1,390 @@
+package twod
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Canvas draws a grid with colored cells, paths through the cells and
+// labeled points, and writes it as a PNG or SVG.
+type Canvas struct {
+	Rows int
+	Cols int
+	// Title names the canvas, it is added to the file name when a day
+	// renders more than one.
+	Title string
+	// CellSize is the size of each cell in pixels, 10 when zero.
+	CellSize int
+	// Background colors cells that haven't been filled, white when nil.
+	Background color.Color
+	// Outline draws the edges between cells of different colors when set,
+	// which outlines regions.
+	Outline color.Color
+
+	cells  map[Pos]color.Color
+	paths  []canvasPath
+	labels []canvasLabel
+}
+
+type canvasPath struct {
+	points []Pos
+	color  color.Color
+}
+
+type canvasLabel struct {
+	pos   Pos
+	text  string
+	color color.Color
+}
+
+func NewCanvas(rows, cols int) *Canvas {
+	return &Canvas{Rows: rows, Cols: cols, cells: make(map[Pos]color.Color)}
+}
+
+// Fill colors a cell.
+func (c *Canvas) Fill(p *Pos, col color.Color) {
+	c.cells[*p] = col
+}
+
+// FillFunc colors every cell with f, cells where f is nil are left alone.
+func (c *Canvas) FillFunc(f func(p *Pos) color.Color) {
+	for r := 0; r < c.Rows; r++ {
+		for col := 0; col < c.Cols; col++ {
+			p := Pos{Row: r, Col: col}
+			if v := f(&p); v != nil {
+				c.cells[p] = v
+			}
+		}
+	}
+}
+
+// Path draws a line through the centers of the cells.
+func (c *Canvas) Path(points []*Pos, col color.Color) {
+	path := canvasPath{points: make([]Pos, len(points)), color: col}
+	for i, p := range points {
+		path.points[i] = *p
+	}
+	c.paths = append(c.paths, path)
+}
+
+// Label marks a cell with a dot and text.
+func (c *Canvas) Label(p *Pos, text string, col color.Color) {
+	c.labels = append(c.labels, canvasLabel{pos: *p, text: text, color: col})
+}
+
+func (c *Canvas) cellSize() int {
+	if c.CellSize <= 0 {
+		return 10
+	}
+	return c.CellSize
+}
+
+func (c *Canvas) background() color.Color {
+	if c.Background == nil {
+		return color.White
+	}
+	return c.Background
+}
+
+func (c *Canvas) cellColor(p Pos) color.Color {
+	if v, ok := c.cells[p]; ok {
+		return v
+	}
+	return c.background()
+}
+
+// outlines calls f for each edge between cells of different colors, with
+// the edge's end points in cell units.
+func (c *Canvas) outlines(f func(x0, y0, x1, y1 int)) {
+	same := func(a, b color.Color) bool {
+		r0, g0, b0, a0 := a.RGBA()
+		r1, g1, b1, a1 := b.RGBA()
+		return r0 == r1 && g0 == g1 && b0 == b1 && a0 == a1
+	}
+	for r := 0; r < c.Rows; r++ {
+		for col := 0; col < c.Cols; col++ {
+			here := c.cellColor(Pos{Row: r, Col: col})
+			if col+1 < c.Cols && !same(here, c.cellColor(Pos{Row: r, Col: col + 1})) {
+				f(col+1, r, col+1, r+1)
+			}
+			if r+1 < c.Rows && !same(here, c.cellColor(Pos{Row: r + 1, Col: col})) {
+				f(col, r+1, col+1, r+1)
+			}
+		}
+	}
+}
+
+// Save writes the canvas to a .png or .svg file.
+func (c *Canvas) Save(path string) error {
+	var write func(io.Writer) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		write = c.WritePNG
+	case ".svg":
+		write = c.WriteSVG
+	default:
+		return fmt.Errorf("%s: unknown image format, want .png or .svg", path)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Image draws the canvas.
+func (c *Canvas) Image() *image.RGBA {
+	size := c.cellSize()
+	img := image.NewRGBA(image.Rect(0, 0, c.Cols*size, c.Rows*size))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c.background()), image.Point{}, draw.Src)
+	for p, col := range c.cells {
+		if p.Row < 0 || p.Col < 0 || p.Row >= c.Rows || p.Col >= c.Cols {
+			continue
+		}
+		rect := image.Rect(p.Col*size, p.Row*size, (p.Col+1)*size, (p.Row+1)*size)
+		draw.Draw(img, rect, image.NewUniform(col), image.Point{}, draw.Src)
+	}
+
+	if c.Outline != nil {
+		c.outlines(func(x0, y0, x1, y1 int) {
+			line(img, x0*size, y0*size, x1*size, y1*size, 1, c.Outline)
+		})
+	}
+
+	width := max(size/4, 1)
+	for _, path := range c.paths {
+		for i := 1; i < len(path.points); i++ {
+			a, b := path.points[i-1], path.points[i]
+			line(img, a.Col*size+size/2, a.Row*size+size/2, b.Col*size+size/2, b.Row*size+size/2, width, path.color)
+		}
+	}
+
+	for _, l := range c.labels {
+		cx, cy := l.pos.Col*size+size/2, l.pos.Row*size+size/2
+		dot(img, cx, cy, max(size/3, 1), l.color)
+		if l.text == "" {
+			continue
+		}
+		d := &font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(l.color),
+			Face: basicfont.Face7x13,
+		}
+		// Text goes right of the dot unless it would be cut off.
+		x, w := cx+size/2+1, d.MeasureString(l.text).Ceil()
+		if x+w > img.Bounds().Dx() {
+			x = cx - size/2 - 1 - w
+		}
+		d.Dot = fixed.P(x, cy+4)
+		d.DrawString(l.text)
+	}
+	return img
+}
+
+// WritePNG encodes the canvas as a PNG.
+func (c *Canvas) WritePNG(w io.Writer) error {
+	return png.Encode(w, c.Image())
+}
+
+// line draws a line of the width with Bresenham's algorithm.
+func line(img *image.RGBA, x0, y0, x1, y1, width int, col color.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	half := width / 2
+	e := dx + dy
+	for {
+		draw.Draw(img, image.Rect(x0-half, y0-half, x0-half+width, y0-half+width), image.NewUniform(col), image.Point{}, draw.Src)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * e; e2 >= dy {
+			e += dy
+			x0 += sx
+		} else {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func dot(img *image.RGBA, cx, cy, r int, col color.Color) {
+	for y := -r; y <= r; y++ {
+		for x := -r; x <= r; x++ {
+			if x*x+y*y <= r*r {
+				img.Set(cx+x, cy+y, col)
+			}
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// WriteSVG writes the canvas as an SVG with one rect per filled cell.
+func (c *Canvas) WriteSVG(w io.Writer) error {
+	size := c.cellSize()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		c.Cols*size, c.Rows*size, c.Cols*size, c.Rows*size)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hex(c.background()))
+	for r := 0; r < c.Rows; r++ {
+		for col := 0; col < c.Cols; col++ {
+			if v, ok := c.cells[Pos{Row: r, Col: col}]; ok {
+				fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+					col*size, r*size, size, size, hex(v))
+			}
+		}
+	}
+	if c.Outline != nil {
+		fmt.Fprintf(bw, `<path stroke="%s" stroke-width="1" d="`, hex(c.Outline))
+		c.outlines(func(x0, y0, x1, y1 int) {
+			fmt.Fprintf(bw, "M%d %dL%d %d", x0*size, y0*size, x1*size, y1*size)
+		})
+		fmt.Fprintln(bw, `"/>`)
+	}
+	for _, path := range c.paths {
+		pts := make([]string, len(path.points))
+		for i, p := range path.points {
+			pts[i] = fmt.Sprintf("%d,%d", p.Col*size+size/2, p.Row*size+size/2)
+		}
+		fmt.Fprintf(bw, `<polyline fill="none" stroke="%s" stroke-width="%d" stroke-linejoin="round" points="%s"/>`+"\n",
+			hex(path.color), max(size/4, 1), strings.Join(pts, " "))
+	}
+	for _, l := range c.labels {
+		cx, cy := l.pos.Col*size+size/2, l.pos.Row*size+size/2
+		fmt.Fprintf(bw, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n", cx, cy, max(size/3, 1), hex(l.color))
+		if l.text != "" {
+			x, anchor := cx+size/2+1, "start"
+			if l.pos.Col > c.Cols/2 {
+				x, anchor = cx-size/2-1, "end"
+			}
+			fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="%s" font-family="monospace" font-size="%d" fill="%s">%s</text>`+"\n",
+				x, cy+4, anchor, max(size, 10), hex(l.color), html.EscapeString(l.text))
+		}
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+func hex(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+// Distinct returns the i-th of a sequence of colors that are easy to tell
+// apart, for coloring regions.
+func Distinct(i int) color.Color {
+	// Stepping the hue by the golden ratio spreads out any number of colors.
+	h := math.Mod(float64(i)*0.618033988749895, 1)
+	return hsv(h, 0.55, 0.95)
+}
+
+// Heat returns a color from dark blue at 0 to yellow at 1.
+func Heat(v float64) color.Color {
+	v = max(0, min(v, 1))
+	return hsv(0.66-0.5*v, 0.9, 0.35+0.65*v)
+}
+
+func hsv(h, s, v float64) color.Color {
+	i := math.Floor(h * 6)
+	f := h*6 - i
+	p, q, t := v*(1-s), v*(1-f*s), v*(1-(1-f)*s)
+	var r, g, b float64
+	switch int(i) % 6 {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	default:
+		r, g, b = v, p, q
+	}
+	return color.RGBA{uint8(r * 255), uint8(g * 255), uint8(b * 255), 0xff}
+}
+
+type contextKey string
+
+const rendererKey = contextKey("renderer")
+
+// Renderer collects the canvases that solvers draw their results on.
+// Solvers get it with RendererFromContext, which is nil and records nothing
+// unless rendering was asked for.
+type Renderer struct {
+	mu       sync.Mutex
+	canvases []*Canvas
+}
+
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+// WithRenderer returns a context that carries the renderer.
+func WithRenderer(ctx context.Context, r *Renderer) context.Context {
+	return context.WithValue(ctx, rendererKey, r)
+}
+
+// RendererFromContext returns the renderer in the context, or nil.
+func RendererFromContext(ctx context.Context) *Renderer {
+	r, _ := ctx.Value(rendererKey).(*Renderer)
+	return r
+}
+
+// Enabled is false for a nil renderer, so solvers can skip drawing.
+func (r *Renderer) Enabled() bool {
+	return r != nil
+}
+
+// Add keeps a canvas to be saved.
+func (r *Renderer) Add(c *Canvas) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.canvases = append(r.canvases, c)
+}
+
+// Canvases returns the canvases added so far.
+func (r *Renderer) Canvases() []*Canvas {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.canvases
+}
@@ -0,0 +1,62 @@
+package twod_test
+
+import (
+	"bytes"
+	"context"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/mikehelmick/adventofcode/pkg/twod"
+)
+
+func TestCanvas(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	c := twod.NewCanvas(3, 4)
+	c.CellSize = 5
+	c.Fill(twod.NewPos(0, 0), red)
+	c.Path([]*twod.Pos{twod.NewPos(2, 0), twod.NewPos(2, 3)}, color.Black)
+	c.Label(twod.NewPos(1, 3), "end & <done>", color.Black)
+
+	var buf bytes.Buffer
+	if err := c.WritePNG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 20 || b.Dy() != 15 {
+		t.Errorf("size, want: 20x15 got: %dx%d", b.Dx(), b.Dy())
+	}
+	if r, g, _, _ := img.At(2, 2).RGBA(); r != 0xffff || g != 0 {
+		t.Errorf("filled cell, want: red got: %v", img.At(2, 2))
+	}
+	if r, _, _, _ := img.At(10, 12).RGBA(); r != 0 {
+		t.Errorf("path, want: black got: %v", img.At(10, 12))
+	}
+
+	buf.Reset()
+	if err := c.WriteSVG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	for _, want := range []string{`fill="#ff0000"`, `points="2,12 17,12"`, "end &amp; &lt;done&gt;"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("svg missing %q:\n%s", want, svg)
+		}
+	}
+}
+
+func TestRenderer(t *testing.T) {
+	if r := twod.RendererFromContext(context.Background()); r.Enabled() {
+		t.Errorf("no renderer should be disabled")
+	}
+	r := twod.NewRenderer()
+	ctx := twod.WithRenderer(context.Background(), r)
+	twod.RendererFromContext(ctx).Add(twod.NewCanvas(1, 1))
+	if len(r.Canvases()) != 1 {
+		t.Errorf("canvases, want: 1 got: %v", len(r.Canvases()))
+	}
+}